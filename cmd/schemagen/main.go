@@ -0,0 +1,841 @@
+// Command schemagen generates the Go side of a GraphQL schema from its SDL: enum types, input types, argument types,
+// the interfaces of the resolvers the schema needs and the table wiring them into the schema.
+//
+// Object types are bound to the Go struct of the same name in the package of the generated file, or to the struct
+// given with -bind. A field is read straight from the struct field graphql-go would resolve it from (same name, or
+// json tag) when the Go type of that field fits the GraphQL type. Every other field, every field with arguments and
+// every field of the root types gets a method on the resolver interface of its type, so a schema change that the Go
+// models don't follow fails to compile instead of resolving to null.
+//
+// It is run by go generate in the weather package:
+//
+//	go run ../cmd/schemagen -schema schema.graphql -out schema_gen.go -bind CurrentConditions=CurrentWeatherInfo
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	goast "go/ast"
+	"go/format"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// bindings binds GraphQL object types to Go structs, set with repeated -bind Type=Struct flags
+type bindings map[string]string
+
+func (b bindings) String() string {
+	return fmt.Sprint(map[string]string(b))
+}
+
+func (b bindings) Set(value string) error {
+	object, goType, ok := strings.Cut(value, "=")
+	if !ok || object == "" || goType == "" {
+		return fmt.Errorf("%q is not Type=Struct", value)
+	}
+	b[object] = goType
+	return nil
+}
+
+// fieldSet is a set of fields, set with repeated Type.field flags
+type fieldSet map[string]bool
+
+func (f fieldSet) String() string {
+	return strings.Join(sortedKeys(f), ",")
+}
+
+func (f fieldSet) Set(value string) error {
+	if object, field, ok := strings.Cut(value, "."); !ok || object == "" || field == "" {
+		return fmt.Errorf("%q is not Type.field", value)
+	}
+	f[value] = true
+	return nil
+}
+
+func main() {
+	schemaFile := flag.String("schema", "schema.graphql", "SDL to generate the Go code of")
+	outFile := flag.String("out", "schema_gen.go", "generated file, in the package holding the Go models")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file")
+	binds := bindings{}
+	flag.Var(binds, "bind", "binds an object type to a Go struct of another name, as Type=Struct")
+	batched := fieldSet{}
+	flag.Var(batched, "batch", "resolves a field with a thunk so loads can be batched across siblings, as Type.field")
+	flag.Parse()
+
+	if err := run(*schemaFile, *outFile, *pkg, binds, batched); err != nil {
+		fmt.Fprintln(os.Stderr, "schemagen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaFile, outFile, pkg string, binds bindings, batched fieldSet) error {
+	if pkg == "" {
+		return fmt.Errorf("no package, run through go generate or set -pkg")
+	}
+	sdl, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return err
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: string(sdl)})
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %v", schemaFile, err)
+	}
+	models, err := loadModels(filepath.Dir(outFile), filepath.Base(outFile))
+	if err != nil {
+		return err
+	}
+
+	g, err := newGenerator(doc, models, binds, batched)
+	if err != nil {
+		return err
+	}
+	source, err := g.generate(pkg, filepath.Base(schemaFile), fmt.Sprintf("%x", sha256.Sum256(sdl)))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outFile, source, 0o644)
+}
+
+// goType is the type of a struct field, as far as it matters to tell whether it fits a GraphQL type
+type goType struct {
+	// kind is basic, named, pointer, slice, time or other
+	kind string
+	name string
+	elem *goType
+}
+
+// goField is an exported struct field
+type goField struct {
+	name     string
+	typ      goType
+	jsonName string
+	tagName  string
+}
+
+// models are the types declared in the package the code is generated in
+type models struct {
+	structs map[string][]goField
+	// basics maps the named non-struct types to the basic type they are declared with
+	basics map[string]string
+}
+
+// loadModels reads the types declared in the Go files of a directory, leaving out tests and the generated file
+func loadModels(dir, generated string) (*models, error) {
+	fset := token.NewFileSet()
+	pkgs, err := goparser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return info.Name() != generated && !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &models{structs: map[string][]goField{}, basics: map[string]string{}}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*goast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					spec := spec.(*goast.TypeSpec)
+					switch t := spec.Type.(type) {
+					case *goast.StructType:
+						m.structs[spec.Name.Name] = structFields(t)
+					case *goast.Ident:
+						m.basics[spec.Name.Name] = t.Name
+					}
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// structFields lists the exported fields of a struct in declaration order, which is the order graphql-go matches
+// them in
+func structFields(s *goast.StructType) []goField {
+	var fields []goField
+	for _, field := range s.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			value, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+		tagName, _, _ := strings.Cut(tag.Get("graphql"), ",")
+
+		names := field.Names
+		if len(names) == 0 {
+			// Embedded fields are matched by their type name
+			names = []*goast.Ident{embeddedName(field.Type)}
+		}
+		for _, name := range names {
+			if name == nil || !name.IsExported() {
+				continue
+			}
+			fields = append(fields, goField{name: name.Name, typ: toGoType(field.Type), jsonName: jsonName, tagName: tagName})
+		}
+	}
+	return fields
+}
+
+func embeddedName(expr goast.Expr) *goast.Ident {
+	switch t := expr.(type) {
+	case *goast.Ident:
+		return t
+	case *goast.StarExpr:
+		return embeddedName(t.X)
+	case *goast.SelectorExpr:
+		return t.Sel
+	}
+	return nil
+}
+
+func toGoType(expr goast.Expr) goType {
+	switch t := expr.(type) {
+	case *goast.Ident:
+		switch t.Name {
+		case "string", "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32",
+			"uint64", "float32", "float64":
+			return goType{kind: "basic", name: t.Name}
+		}
+		return goType{kind: "named", name: t.Name}
+	case *goast.StarExpr:
+		elem := toGoType(t.X)
+		return goType{kind: "pointer", elem: &elem}
+	case *goast.ArrayType:
+		elem := toGoType(t.Elt)
+		return goType{kind: "slice", elem: &elem}
+	case *goast.SelectorExpr:
+		if pkg, ok := t.X.(*goast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return goType{kind: "time"}
+		}
+	}
+	return goType{kind: "other"}
+}
+
+// scalarKinds are the Go basic types each built-in scalar serializes from
+var scalarKinds = map[string][]string{
+	"String":  {"string"},
+	"ID":      {"string", "int", "int64"},
+	"Boolean": {"bool"},
+	"Int":     {"int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64"},
+	"Float": {"float32", "float64", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32",
+		"uint64"},
+}
+
+// scalarTypes are the Go types scalars are decoded into and resolved from
+var scalarTypes = map[string]string{
+	"String":   "string",
+	"ID":       "string",
+	"Boolean":  "bool",
+	"Int":      "int",
+	"Float":    "float64",
+	"DateTime": "time.Time",
+}
+
+// field is a field of an object type and how it is resolved
+type field struct {
+	def    *ast.FieldDefinition
+	goName string
+	// structField is the struct field the value is read from, empty for fields with a resolver
+	structField string
+	// reason tells why a field without arguments needs a resolver
+	reason string
+}
+
+// object is an object type with its fields
+type object struct {
+	def    *ast.ObjectDefinition
+	name   string
+	goType string
+	root   bool
+	fields []*field
+}
+
+// resolved returns the fields of an object with a resolver
+func (o *object) resolved() []*field {
+	var fields []*field
+	for _, f := range o.fields {
+		if f.structField == "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+type generator struct {
+	models  *models
+	batched fieldSet
+
+	roots        map[string]string
+	enums        []*ast.EnumDefinition
+	inputs       []*ast.InputObjectDefinition
+	objects      []*object
+	kinds        map[string]string
+	usesTime     bool
+	subscription string
+}
+
+func newGenerator(doc *ast.Document, m *models, binds bindings, batched fieldSet) (*generator, error) {
+	g := &generator{models: m, batched: batched, roots: map[string]string{}, kinds: map[string]string{}}
+	for name := range scalarTypes {
+		g.kinds[name] = "scalar"
+	}
+
+	var objects []*ast.ObjectDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			for _, operation := range def.OperationTypes {
+				g.roots[operation.Type.Name.Value] = operation.Operation
+			}
+		case *ast.ScalarDefinition:
+			if _, ok := scalarTypes[def.Name.Value]; !ok {
+				return nil, fmt.Errorf("scalar %s has no Go type", def.Name.Value)
+			}
+		case *ast.EnumDefinition:
+			g.enums = append(g.enums, def)
+			g.kinds[def.Name.Value] = "enum"
+		case *ast.InputObjectDefinition:
+			g.inputs = append(g.inputs, def)
+			g.kinds[def.Name.Value] = "input"
+		case *ast.ObjectDefinition:
+			objects = append(objects, def)
+			g.kinds[def.Name.Value] = "object"
+		default:
+			return nil, fmt.Errorf("%s definitions are not supported", def.GetKind())
+		}
+	}
+	if len(g.roots) == 0 {
+		return nil, fmt.Errorf("the schema has no schema definition")
+	}
+
+	bound := map[string]string{}
+	for _, def := range objects {
+		name := def.Name.Value
+		if _, root := g.roots[name]; root {
+			continue
+		}
+		goType := name
+		if b, ok := binds[name]; ok {
+			goType = b
+		}
+		if _, ok := m.structs[goType]; !ok {
+			return nil, fmt.Errorf("object type %s has no Go struct %s, declare it or bind another one with -bind", name, goType)
+		}
+		bound[name] = goType
+	}
+	for name := range binds {
+		if _, ok := bound[name]; !ok {
+			return nil, fmt.Errorf("-bind %s does not name an object type", name)
+		}
+	}
+
+	for _, def := range objects {
+		name := def.Name.Value
+		o := &object{def: def, name: name, goType: bound[name]}
+		_, o.root = g.roots[name]
+		if g.roots[name] == "subscription" {
+			g.subscription = name
+		}
+
+		taken := map[string]bool{}
+		for _, fieldDef := range sortedFields(def.Fields) {
+			f := &field{def: fieldDef, goName: uniqueGoName(fieldDef.Name.Value, taken)}
+			key := name + "." + fieldDef.Name.Value
+			switch {
+			case o.root:
+			case batched[key]:
+				f.reason = "batched"
+			case len(fieldDef.Arguments) > 0:
+			default:
+				f.structField, f.reason = g.match(o.goType, fieldDef, bound)
+			}
+			o.fields = append(o.fields, f)
+		}
+		g.objects = append(g.objects, o)
+	}
+	for key := range batched {
+		objectName, fieldName, _ := strings.Cut(key, ".")
+		if !hasField(g.objects, objectName, fieldName) {
+			return nil, fmt.Errorf("-batch %s does not name a field", key)
+		}
+	}
+	sort.Slice(g.objects, func(i, j int) bool { return g.objects[i].name < g.objects[j].name })
+	sort.Slice(g.enums, func(i, j int) bool { return g.enums[i].Name.Value < g.enums[j].Name.Value })
+	sort.Slice(g.inputs, func(i, j int) bool { return g.inputs[i].Name.Value < g.inputs[j].Name.Value })
+	return g, nil
+}
+
+func hasField(objects []*object, objectName, fieldName string) bool {
+	for _, o := range objects {
+		if o.name != objectName {
+			continue
+		}
+		for _, f := range o.fields {
+			if f.def.Name.Value == fieldName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortedFields orders fields by name, so fields spelled in camel case get the Go names before their snake case
+// twins
+func sortedFields(fields []*ast.FieldDefinition) []*ast.FieldDefinition {
+	sorted := append([]*ast.FieldDefinition{}, fields...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name.Value < sorted[j].Name.Value })
+	return sorted
+}
+
+// match finds the struct field graphql-go's default resolver would read a field from, the first one named like the
+// field or tagged with its name, and returns it when its Go type fits the field type
+func (g *generator) match(goType string, def *ast.FieldDefinition, bound map[string]string) (string, string) {
+	name := def.Name.Value
+	for _, f := range g.models.structs[goType] {
+		if !strings.EqualFold(f.name, name) && f.jsonName != name && f.tagName != name {
+			continue
+		}
+		if !g.fits(f.typ, def.Type, bound) {
+			return "", fmt.Sprintf("%s.%s does not hold a %s", goType, f.name, typeString(def.Type))
+		}
+		return f.name, ""
+	}
+	return "", fmt.Sprintf("%s has no %s field", goType, name)
+}
+
+// fits reports whether a struct field of a Go type can be served as a GraphQL type
+func (g *generator) fits(t goType, gql ast.Type, bound map[string]string) bool {
+	switch gql := gql.(type) {
+	case *ast.NonNull:
+		return t.kind != "pointer" && g.fits(t, gql.Type, bound)
+	case *ast.List:
+		if t.kind == "pointer" {
+			t = *t.elem
+		}
+		return t.kind == "slice" && g.fits(*t.elem, gql.Type, bound)
+	case *ast.Named:
+		if t.kind == "pointer" {
+			t = *t.elem
+		}
+		name := gql.Name.Value
+		switch g.kinds[name] {
+		case "object":
+			return t.kind == "named" && t.name == bound[name]
+		case "enum":
+			return t.kind == "named" && t.name == name || g.basic(t) == "string"
+		case "scalar":
+			if name == "DateTime" {
+				return t.kind == "time"
+			}
+			for _, kind := range scalarKinds[name] {
+				if g.basic(t) == kind {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// basic returns the basic type a Go type is declared with, empty for other types
+func (g *generator) basic(t goType) string {
+	switch t.kind {
+	case "basic":
+		return t.name
+	case "named":
+		return g.models.basics[t.name]
+	}
+	return ""
+}
+
+// generate writes the generated file
+func (g *generator) generate(pkg, schemaFile, hash string) ([]byte, error) {
+	var body bytes.Buffer
+	g.writeEnums(&body)
+	g.writeInputs(&body)
+	g.writeArgs(&body)
+	g.writeResolvers(&body)
+	g.writeTable(&body)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by schemagen from %s. DO NOT EDIT.\n\npackage %s\n\n", schemaFile, pkg)
+	out.WriteString("import (\n\t\"context\"\n")
+	if g.usesTime {
+		out.WriteString("\t\"time\"\n")
+	}
+	out.WriteString("\n\t\"github.com/graphql-go/graphql\"\n)\n\n")
+	fmt.Fprintf(&out, "// schemaHash is the SHA-256 of the %s this file was generated from\n", schemaFile)
+	fmt.Fprintf(&out, "const schemaHash = %q\n\n", hash)
+	out.Write(body.Bytes())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go: %v\n%s", err, out.Bytes())
+	}
+	return source, nil
+}
+
+func (g *generator) writeEnums(w *bytes.Buffer) {
+	for _, enum := range g.enums {
+		name := enum.Name.Value
+		writeDoc(w, "", name+" is the GraphQL enum "+name, description(enum.Description))
+		fmt.Fprintf(w, "type %s string\n\n", name)
+		fmt.Fprintf(w, "// Values of %s\nconst (\n", name)
+		for _, value := range enum.Values {
+			if d := description(value.Description); d != "" {
+				fmt.Fprintf(w, "\t// %s%s %s\n", name, goName(strings.ToLower(value.Name.Value)), d)
+			}
+			fmt.Fprintf(w, "\t%s%s %s = %q\n", name, goName(strings.ToLower(value.Name.Value)), name, value.Name.Value)
+		}
+		w.WriteString(")\n\n")
+	}
+}
+
+func (g *generator) writeInputs(w *bytes.Buffer) {
+	for _, input := range g.inputs {
+		name := input.Name.Value
+		writeDoc(w, "", name+" is the GraphQL input "+name, description(input.Description))
+		g.writeStruct(w, name, input.Fields)
+		g.writeDecoder(w, name, "decode"+name, "value interface{}", input.Fields)
+	}
+}
+
+func (g *generator) writeArgs(w *bytes.Buffer) {
+	for _, o := range g.objects {
+		for _, f := range o.resolved() {
+			if len(f.def.Arguments) == 0 {
+				continue
+			}
+			name := argsName(o, f)
+			fmt.Fprintf(w, "// %s are the arguments of %s.%s\n", name, o.name, f.def.Name.Value)
+			g.writeStruct(w, name, f.def.Arguments)
+			g.writeDecoder(w, name, "decode"+name, "value interface{}", f.def.Arguments)
+		}
+	}
+}
+
+func (g *generator) writeStruct(w *bytes.Buffer, name string, values []*ast.InputValueDefinition) {
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	taken := map[string]bool{}
+	for _, value := range values {
+		if d := description(value.Description); d != "" {
+			fmt.Fprintf(w, "\t// %s\n", d)
+		}
+		fmt.Fprintf(w, "\t%s %s\n", uniqueGoName(value.Name.Value, taken), g.inputType(value.Type, value.DefaultValue != nil))
+	}
+	w.WriteString("}\n\n")
+}
+
+// writeDecoder writes the function decoding the arguments or input object values graphql-go coerced, defaults
+// included, into their Go type
+func (g *generator) writeDecoder(w *bytes.Buffer, name, function, param string, values []*ast.InputValueDefinition) {
+	fmt.Fprintf(w, "// %s converts the values graphql-go coerced, defaults included, to %s\n", function, name)
+	fmt.Fprintf(w, "func %s(%s) %s {\n", function, param, name)
+	if len(values) == 0 {
+		fmt.Fprintf(w, "\treturn %s{}\n}\n\n", name)
+		return
+	}
+	w.WriteString("\tfields, _ := value.(map[string]interface{})\n")
+	fmt.Fprintf(w, "\treturn %s{\n", name)
+	taken := map[string]bool{}
+	for _, value := range values {
+		fmt.Fprintf(w, "\t\t%s: %s,\n", uniqueGoName(value.Name.Value, taken),
+			g.decodeExpr(value.Type, value.DefaultValue != nil, fmt.Sprintf("fields[%q]", value.Name.Value)))
+	}
+	w.WriteString("\t}\n}\n\n")
+}
+
+// inputType is the Go type of an argument or input field. Nullable scalars, enums and input objects without a
+// default are pointers, nil when they are not set.
+func (g *generator) inputType(t ast.Type, hasDefault bool) string {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return g.inputType(t.Type, true)
+	case *ast.List:
+		return "[]" + g.inputType(t.Type, true)
+	case *ast.Named:
+		goType := g.namedType(t.Name.Value)
+		if hasDefault {
+			return goType
+		}
+		return "*" + goType
+	}
+	return "interface{}"
+}
+
+// decodeExpr is the expression decoding a coerced value into its input type
+func (g *generator) decodeExpr(t ast.Type, hasDefault bool, value string) string {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return g.decodeExpr(t.Type, true, value)
+	case *ast.List:
+		return fmt.Sprintf("decodeList(%s, %s)", value, g.decodeFunc(t.Type))
+	case *ast.Named:
+		name := t.Name.Value
+		switch g.kinds[name] {
+		case "enum":
+			if hasDefault {
+				return fmt.Sprintf("decodeEnum[%s](%s)", name, value)
+			}
+			return fmt.Sprintf("decodeEnumPointer[%s](%s)", name, value)
+		case "input":
+			if hasDefault {
+				return fmt.Sprintf("decode%s(%s)", name, value)
+			}
+			return fmt.Sprintf("decodePointer(%s, decode%s)", value, name)
+		}
+		if hasDefault {
+			return fmt.Sprintf("decodeValue[%s](%s)", g.namedType(name), value)
+		}
+		return fmt.Sprintf("decodeValuePointer[%s](%s)", g.namedType(name), value)
+	}
+	return value
+}
+
+// decodeFunc is the function decoding the items of a list
+func (g *generator) decodeFunc(t ast.Type) string {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return g.decodeFunc(t.Type)
+	case *ast.List:
+		return fmt.Sprintf("func(value interface{}) %s { return %s }", g.inputType(t, true), g.decodeExpr(t, true, "value"))
+	case *ast.Named:
+		name := t.Name.Value
+		switch g.kinds[name] {
+		case "enum":
+			return fmt.Sprintf("decodeEnum[%s]", name)
+		case "input":
+			return "decode" + name
+		}
+		return fmt.Sprintf("decodeValue[%s]", g.namedType(name))
+	}
+	return ""
+}
+
+// namedType is the Go type of a named GraphQL type
+func (g *generator) namedType(name string) string {
+	if goType, ok := scalarTypes[name]; ok {
+		if goType == "time.Time" {
+			g.usesTime = true
+		}
+		return goType
+	}
+	for _, o := range g.objects {
+		if o.name == name {
+			return o.goType
+		}
+	}
+	return name
+}
+
+// outputType is the Go type a resolver returns for a GraphQL type. Nullable scalars and enums are pointers, nil for
+// null, and objects are always pointers.
+func (g *generator) outputType(t ast.Type, nullable bool) string {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return g.outputType(t.Type, false)
+	case *ast.List:
+		return "[]" + g.outputType(t.Type, true)
+	case *ast.Named:
+		goType := g.namedType(t.Name.Value)
+		if nullable || g.kinds[t.Name.Value] == "object" {
+			return "*" + goType
+		}
+		return goType
+	}
+	return "interface{}"
+}
+
+func (g *generator) writeResolvers(w *bytes.Buffer) {
+	w.WriteString("// ResolverRoot gives the resolvers of the fields the schema can't read from the Go models\n")
+	w.WriteString("type ResolverRoot interface {\n")
+	for _, o := range g.objects {
+		if len(o.resolved()) > 0 {
+			fmt.Fprintf(w, "\t%s() %sResolver\n", o.name, o.name)
+		}
+	}
+	w.WriteString("}\n\n")
+
+	for _, o := range g.objects {
+		fields := o.resolved()
+		if len(fields) == 0 {
+			continue
+		}
+		if o.root {
+			fmt.Fprintf(w, "// %sResolver resolves the fields of %s\n", o.name, o.name)
+		} else {
+			fmt.Fprintf(w, "// %sResolver resolves the fields of %s that are not read from %s\n", o.name, o.name, o.goType)
+		}
+		fmt.Fprintf(w, "type %sResolver interface {\n", o.name)
+		for i, f := range fields {
+			if i > 0 {
+				w.WriteString("\n")
+			}
+			doc := description(f.def.Description)
+			if f.reason != "" && f.reason != "batched" {
+				if doc != "" {
+					doc += "\n"
+				}
+				doc += "Resolved because " + f.reason + "."
+			}
+			writeDoc(w, "\t", f.goName+" resolves "+o.name+"."+f.def.Name.Value, doc)
+			fmt.Fprintf(w, "\t%s\n", g.signature(o, f))
+		}
+		w.WriteString("}\n\n")
+	}
+}
+
+func (g *generator) signature(o *object, f *field) string {
+	params := []string{"ctx context.Context"}
+	if !o.root {
+		params = append(params, "obj *"+o.goType)
+	}
+	if len(f.def.Arguments) > 0 {
+		params = append(params, "args "+argsName(o, f))
+	}
+	result := g.outputType(f.def.Type, true)
+	switch {
+	case o.name == g.subscription:
+		return fmt.Sprintf("%s(%s) (<-chan %s, error)", f.goName, strings.Join(params, ", "), result)
+	case f.reason == "batched":
+		return fmt.Sprintf("%s(%s) (func() (%s, error), error)", f.goName, strings.Join(params, ", "), result)
+	}
+	return fmt.Sprintf("%s(%s) (%s, error)", f.goName, strings.Join(params, ", "), result)
+}
+
+func (g *generator) writeTable(w *bytes.Buffer) {
+	w.WriteString("// schemaResolvers resolves every field of the schema, keyed by Type.field\n")
+	w.WriteString("func schemaResolvers(r ResolverRoot) map[string]fieldResolver {\n")
+	w.WriteString("\treturn map[string]fieldResolver{\n")
+	for _, o := range g.objects {
+		for _, f := range o.fields {
+			key := o.name + "." + f.def.Name.Value
+			if f.structField != "" {
+				fmt.Fprintf(w, "\t\t%q: {Resolve: structField(func(obj *%s) interface{} { return obj.%s }), ReadsStruct: true},\n", key, o.goType, f.structField)
+				continue
+			}
+
+			args := []string{"p.Context"}
+			if !o.root {
+				args = append(args, "obj")
+			}
+			if len(f.def.Arguments) > 0 {
+				args = append(args, fmt.Sprintf("decode%s(p.Args)", argsName(o, f)))
+			}
+			call := fmt.Sprintf("r.%s().%s(%s)", o.name, f.goName, strings.Join(args, ", "))
+
+			fmt.Fprintf(w, "\t\t%q: {\n", key)
+			if o.name == g.subscription {
+				w.WriteString("\t\t\tSubscribe: func(p graphql.ResolveParams) (interface{}, error) {\n")
+				fmt.Fprintf(w, "\t\t\t\tevents, err := %s\n", call)
+				w.WriteString("\t\t\t\tif err != nil {\n\t\t\t\t\treturn nil, err\n\t\t\t\t}\n")
+				w.WriteString("\t\t\t\treturn forward(p.Context, events), nil\n\t\t\t},\n")
+				w.WriteString("\t\t\tResolve: func(p graphql.ResolveParams) (interface{}, error) {\n")
+				w.WriteString("\t\t\t\treturn p.Source, nil\n\t\t\t},\n")
+				w.WriteString("\t\t},\n")
+				continue
+			}
+
+			w.WriteString("\t\t\tResolve: func(p graphql.ResolveParams) (interface{}, error) {\n")
+			if !o.root {
+				fmt.Fprintf(w, "\t\t\t\tobj, ok := sourceOf[%s](p.Source)\n", o.goType)
+				w.WriteString("\t\t\t\tif !ok {\n\t\t\t\t\treturn nil, nil\n\t\t\t\t}\n")
+			}
+			if f.reason == "batched" {
+				fmt.Fprintf(w, "\t\t\t\tload, err := %s\n", call)
+				w.WriteString("\t\t\t\tif err != nil {\n\t\t\t\t\treturn nil, err\n\t\t\t\t}\n")
+				w.WriteString("\t\t\t\treturn func() (interface{}, error) { return resolved(load()) }, nil\n")
+			} else {
+				fmt.Fprintf(w, "\t\t\t\treturn resolved(%s)\n", call)
+			}
+			w.WriteString("\t\t\t},\n\t\t},\n")
+		}
+	}
+	w.WriteString("\t}\n}\n")
+}
+
+func argsName(o *object, f *field) string {
+	return o.name + f.goName + "Args"
+}
+
+// writeDoc writes the doc comment of a declaration, followed by the description of its definition
+func writeDoc(w *bytes.Buffer, indent, head, doc string) {
+	fmt.Fprintf(w, "%s// %s\n", indent, head)
+	if doc == "" {
+		return
+	}
+	fmt.Fprintf(w, "%s//\n", indent)
+	for _, line := range strings.Split(doc, "\n") {
+		fmt.Fprintf(w, "%s// %s\n", indent, line)
+	}
+}
+
+func description(value *ast.StringValue) string {
+	if value == nil {
+		return ""
+	}
+	return value.Value
+}
+
+// goName turns a GraphQL name into an exported Go name, e.g. temperature_2m_max into Temperature2mMax and id into ID
+func goName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	goName := strings.Join(parts, "")
+	for _, initialism := range []string{"Id", "Url"} {
+		if goName == initialism {
+			return strings.ToUpper(initialism)
+		}
+	}
+	return goName
+}
+
+// uniqueGoName returns the Go name of a field, falling back to its GraphQL name when another field took the name
+func uniqueGoName(name string, taken map[string]bool) string {
+	n := goName(name)
+	if taken[n] {
+		n = strings.ToUpper(name[:1]) + name[1:]
+	}
+	taken[n] = true
+	return n
+}
+
+func typeString(t ast.Type) string {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return typeString(t.Type) + "!"
+	case *ast.List:
+		return "[" + typeString(t.Type) + "]"
+	case *ast.Named:
+		return t.Name.Value
+	}
+	return ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+// Duration is a time.Duration read and written as a string such as "10m"
+type Duration time.Duration
+
+// UnmarshalText parses a duration such as "10m"
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// MarshalText formats a duration such as "10m0s"
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// Config is the configuration of the server. Each setting can be given in a YAML or TOML file, in the environment
+// variable of its env tag or with a flag named after its path in the file, e.g. -server.addr, later sources winning.
+type Config struct {
+	Server       ServerConfig       `yaml:"server" toml:"server"`
+	Storage      StorageConfig      `yaml:"storage" toml:"storage"`
+	Providers    ProvidersConfig    `yaml:"providers" toml:"providers"`
+	Cache        CacheConfig        `yaml:"cache" toml:"cache"`
+	Alerts       AlertsConfig       `yaml:"alerts" toml:"alerts"`
+	Verification VerificationConfig `yaml:"verification" toml:"verification"`
+	Features     FeaturesConfig     `yaml:"features" toml:"features"`
+}
+
+// ServerConfig configures the HTTP server
+type ServerConfig struct {
+	Addr string `yaml:"addr" toml:"addr" env:"LISTEN_ADDR" usage:"address the HTTP server listens on"`
+	// CORSOrigins are the origins allowed to call the API, every origin when it holds "*"
+	CORSOrigins []string `yaml:"cors_origins" toml:"cors_origins" env:"CORS_ORIGINS" usage:"comma-separated origins allowed by CORS, * for any"`
+	// ShutdownDelay is how long /readyz fails on shutdown before the server stops accepting requests
+	ShutdownDelay Duration `yaml:"shutdown_delay" toml:"shutdown_delay" env:"SHUTDOWN_DELAY" usage:"time /readyz fails before the server stops accepting requests on shutdown"`
+	// DrainTimeout bounds how long in-flight requests and background work may take to finish on shutdown
+	DrainTimeout Duration `yaml:"drain_timeout" toml:"drain_timeout" env:"DRAIN_TIMEOUT" usage:"time in-flight requests and background work get to finish on shutdown"`
+}
+
+// StorageConfig configures where data is stored
+type StorageConfig struct {
+	Backend string `yaml:"backend" toml:"backend" env:"STORAGE_BACKEND" usage:"storage backend, scribble"`
+	Path    string `yaml:"path" toml:"path" env:"STORAGE_PATH" usage:"directory of the scribble database"`
+}
+
+// ProvidersConfig configures the weather providers
+type ProvidersConfig struct {
+	ForecastURL string   `yaml:"forecast_url" toml:"forecast_url" env:"OPEN_METEO_FORECAST_URL" usage:"Open-Meteo forecast endpoint"`
+	ArchiveURL  string   `yaml:"archive_url" toml:"archive_url" env:"OPEN_METEO_ARCHIVE_URL" usage:"Open-Meteo historical weather endpoint"`
+	APIKey      string   `yaml:"api_key" toml:"api_key" env:"OPEN_METEO_API_KEY" secret:"true" usage:"Open-Meteo API key of commercial endpoints"`
+	Timeout     Duration `yaml:"timeout" toml:"timeout" env:"OPEN_METEO_TIMEOUT" usage:"time an upstream request may take"`
+	// ArchiveFixtureDir serves observed weather from canned responses instead of the archive endpoint when set
+	ArchiveFixtureDir string `yaml:"archive_fixture_dir" toml:"archive_fixture_dir" env:"ARCHIVE_FIXTURE_DIR" usage:"directory of canned archive responses"`
+}
+
+// CacheConfig configures how long weather data is kept and served
+type CacheConfig struct {
+	RefreshInterval Duration `yaml:"refresh_interval" toml:"refresh_interval" env:"WEATHER_REFRESH_INTERVAL" usage:"interval of background weather refreshes"`
+	// SnapshotMaxAge is how old a snapshot may be and still be served, twice the refresh interval when zero
+	SnapshotMaxAge           Duration `yaml:"snapshot_max_age" toml:"snapshot_max_age" env:"SNAPSHOT_MAX_AGE" usage:"age after which snapshots are refetched, twice the refresh interval when 0"`
+	ForecastHistoryRetention Duration `yaml:"forecast_history_retention" toml:"forecast_history_retention" env:"FORECAST_HISTORY_RETENTION" usage:"how long fetched forecasts are kept, forever when 0"`
+}
+
+// AlertsConfig configures alert evaluation and delivery
+type AlertsConfig struct {
+	// Interval is how often alert rules are evaluated, the refresh interval when zero
+	Interval     Duration `yaml:"interval" toml:"interval" env:"ALERT_INTERVAL" usage:"interval of alert evaluations, the refresh interval when 0"`
+	EmailFrom    string   `yaml:"email_from" toml:"email_from" env:"ALERT_EMAIL_FROM" usage:"sender of alert emails"`
+	OutboxDir    string   `yaml:"outbox_dir" toml:"outbox_dir" env:"ALERT_OUTBOX_DIR" usage:"directory alert emails are written to without an SMTP server"`
+	SMTPAddr     string   `yaml:"smtp_addr" toml:"smtp_addr" env:"ALERT_SMTP_ADDR" usage:"host:port of the SMTP server sending alert emails"`
+	SMTPUsername string   `yaml:"smtp_username" toml:"smtp_username" env:"ALERT_SMTP_USERNAME" usage:"SMTP username"`
+	SMTPPassword string   `yaml:"smtp_password" toml:"smtp_password" env:"ALERT_SMTP_PASSWORD" secret:"true" usage:"SMTP password"`
+}
+
+// VerificationConfig configures forecast verification
+type VerificationConfig struct {
+	Interval Duration `yaml:"interval" toml:"interval" env:"VERIFICATION_INTERVAL" usage:"interval of forecast verifications"`
+}
+
+// FeaturesConfig turns optional parts of the server on and off
+type FeaturesConfig struct {
+	GraphiQL      bool `yaml:"graphiql" toml:"graphiql" env:"FEATURE_GRAPHIQL" usage:"serve GraphiQL"`
+	Subscriptions bool `yaml:"subscriptions" toml:"subscriptions" env:"FEATURE_SUBSCRIPTIONS" usage:"serve subscriptions over WebSocket and SSE"`
+	Alerts        bool `yaml:"alerts" toml:"alerts" env:"FEATURE_ALERTS" usage:"evaluate alert rules"`
+	Webhooks      bool `yaml:"webhooks" toml:"webhooks" env:"FEATURE_WEBHOOKS" usage:"deliver webhook events"`
+	Verification  bool `yaml:"verification" toml:"verification" env:"FEATURE_VERIFICATION" usage:"verify forecasts against observed weather"`
+}
+
+// Default returns the configuration used for settings no source sets
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:          ":3000",
+			CORSOrigins:   []string{"*"},
+			ShutdownDelay: Duration(5 * time.Second),
+			DrainTimeout:  Duration(30 * time.Second),
+		},
+		Storage: StorageConfig{
+			Backend: "scribble",
+			Path:    "./",
+		},
+		Providers: ProvidersConfig{
+			ForecastURL: "https://api.open-meteo.com/v1/forecast",
+			ArchiveURL:  "https://archive-api.open-meteo.com/v1/archive",
+			Timeout:     Duration(30 * time.Second),
+		},
+		Cache: CacheConfig{
+			RefreshInterval:          Duration(10 * time.Minute),
+			ForecastHistoryRetention: Duration(30 * 24 * time.Hour),
+		},
+		Alerts: AlertsConfig{
+			EmailFrom: "alerts@greenheat.local",
+			OutboxDir: "./outbox",
+		},
+		Verification: VerificationConfig{
+			Interval: Duration(24 * time.Hour),
+		},
+		Features: FeaturesConfig{
+			GraphiQL:      true,
+			Subscriptions: true,
+			Alerts:        true,
+			Webhooks:      true,
+			Verification:  true,
+		},
+	}
+}
+
+// SnapshotMaxAge returns how old a snapshot may be and still be served
+func (c *Config) SnapshotMaxAge() time.Duration {
+	if c.Cache.SnapshotMaxAge == 0 {
+		// Snapshots are served until two refreshes were missed
+		return 2 * time.Duration(c.Cache.RefreshInterval)
+	}
+	return time.Duration(c.Cache.SnapshotMaxAge)
+}
+
+// AlertInterval returns how often alert rules are evaluated
+func (c *Config) AlertInterval() time.Duration {
+	if c.Alerts.Interval == 0 {
+		return time.Duration(c.Cache.RefreshInterval)
+	}
+	return time.Duration(c.Alerts.Interval)
+}
+
+// Validate checks every setting, reporting all invalid ones at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	_, _, err := net.SplitHostPort(c.Server.Addr)
+	check(err == nil, "server.addr %q must be host:port: %v", c.Server.Addr, err)
+	for _, origin := range c.Server.CORSOrigins {
+		check(origin == "*" || isHTTPURL(origin), "server.cors_origins: %q must be * or an http(s) origin", origin)
+	}
+	check(c.Server.ShutdownDelay >= 0, "server.shutdown_delay must not be negative")
+	check(c.Server.DrainTimeout > 0, "server.drain_timeout must be positive")
+
+	check(c.Storage.Backend == "scribble", "storage.backend %q is not supported, use scribble", c.Storage.Backend)
+	check(c.Storage.Path != "", "storage.path must be set")
+
+	check(isHTTPURL(c.Providers.ForecastURL), "providers.forecast_url %q must be an http(s) URL", c.Providers.ForecastURL)
+	check(isHTTPURL(c.Providers.ArchiveURL), "providers.archive_url %q must be an http(s) URL", c.Providers.ArchiveURL)
+	check(c.Providers.Timeout > 0, "providers.timeout must be positive")
+
+	check(c.Cache.RefreshInterval > 0, "cache.refresh_interval must be positive")
+	check(c.Cache.SnapshotMaxAge >= 0, "cache.snapshot_max_age must not be negative")
+	check(c.Cache.ForecastHistoryRetention >= 0, "cache.forecast_history_retention must not be negative")
+
+	check(c.Alerts.Interval >= 0, "alerts.interval must not be negative")
+	_, err = mail.ParseAddress(c.Alerts.EmailFrom)
+	check(err == nil, "alerts.email_from %q must be an email address", c.Alerts.EmailFrom)
+	check(c.Alerts.SMTPAddr != "" || c.Alerts.OutboxDir != "", "alerts.outbox_dir must be set without alerts.smtp_addr")
+	if c.Alerts.SMTPAddr != "" {
+		_, _, err = net.SplitHostPort(c.Alerts.SMTPAddr)
+		check(err == nil, "alerts.smtp_addr %q must be host:port", c.Alerts.SMTPAddr)
+	}
+
+	check(c.Verification.Interval > 0, "verification.interval must be positive")
+
+	return errors.Join(errs...)
+}
+
+// isHTTPURL reports whether a string is an absolute http or https URL
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
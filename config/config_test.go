@@ -0,0 +1,94 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		want   []string
+	}{
+		{
+			name:   "defaults",
+			change: func(c *Config) {},
+		},
+		{
+			name:   "address without port",
+			change: func(c *Config) { c.Server.Addr = "localhost" },
+			want:   []string{"server.addr"},
+		},
+		{
+			name:   "invalid CORS origin",
+			change: func(c *Config) { c.Server.CORSOrigins = []string{"*", "example.com"} },
+			want:   []string{"server.cors_origins"},
+		},
+		{
+			name:   "unsupported storage",
+			change: func(c *Config) { c.Storage.Backend = "postgres" },
+			want:   []string{"storage.backend"},
+		},
+		{
+			name:   "forecast URL without scheme",
+			change: func(c *Config) { c.Providers.ForecastURL = "api.open-meteo.com/v1/forecast" },
+			want:   []string{"providers.forecast_url"},
+		},
+		{
+			name: "durations out of range",
+			change: func(c *Config) {
+				c.Server.ShutdownDelay = Duration(-time.Second)
+				c.Cache.RefreshInterval = 0
+			},
+			want: []string{"server.shutdown_delay", "cache.refresh_interval"},
+		},
+		{
+			name:   "invalid sender",
+			change: func(c *Config) { c.Alerts.EmailFrom = "alerts" },
+			want:   []string{"alerts.email_from"},
+		},
+		{
+			name:   "no outbox without SMTP",
+			change: func(c *Config) { c.Alerts.OutboxDir = "" },
+			want:   []string{"alerts.outbox_dir"},
+		},
+		{
+			name: "SMTP without outbox",
+			change: func(c *Config) {
+				c.Alerts.OutboxDir = ""
+				c.Alerts.SMTPAddr = "smtp.example.com:587"
+			},
+		},
+		{
+			name:   "SMTP address without port",
+			change: func(c *Config) { c.Alerts.SMTPAddr = "smtp.example.com" },
+			want:   []string{"alerts.smtp_addr"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := Default()
+			test.change(c)
+			err := c.Validate()
+			if len(test.want) == 0 {
+				if err != nil {
+					t.Errorf("Validate = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate accepted the configuration, want errors on %v", test.want)
+			}
+			if lines := strings.Split(err.Error(), "\n"); len(lines) != len(test.want) {
+				t.Errorf("Validate reported %d errors, want %d: %v", len(lines), len(test.want), err)
+			}
+			for _, setting := range test.want {
+				if !strings.Contains(err.Error(), setting) {
+					t.Errorf("Validate = %v, want an error on %s", err, setting)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets in printed configurations
+const redacted = "********"
+
+// setting is a leaf of the configuration
+type setting struct {
+	path  string
+	field reflect.StructField
+	value reflect.Value
+}
+
+// settings lists the leaves of a configuration, named by their path in configuration files
+func settings(c *Config) []setting {
+	var leaves []setting
+	var walk func(value reflect.Value, prefix string)
+	walk = func(value reflect.Value, prefix string) {
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			path := prefix + field.Tag.Get("yaml")
+			if field.Type.Kind() == reflect.Struct {
+				walk(value.Field(i), path+".")
+				continue
+			}
+			leaves = append(leaves, setting{path: path, field: field, value: value.Field(i)})
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "")
+	return leaves
+}
+
+// set parses a raw value into a setting
+func (s setting) set(raw string) error {
+	var err error
+	switch s.value.Interface().(type) {
+	case Duration:
+		var duration time.Duration
+		if duration, err = time.ParseDuration(raw); err == nil {
+			s.value.Set(reflect.ValueOf(Duration(duration)))
+		}
+	case bool:
+		var enabled bool
+		if enabled, err = strconv.ParseBool(raw); err == nil {
+			s.value.SetBool(enabled)
+		}
+	case []string:
+		var values []string
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		s.value.Set(reflect.ValueOf(values))
+	default:
+		s.value.SetString(raw)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %v", s.path, raw, err)
+	}
+	return nil
+}
+
+// Load reads the configuration from its defaults, the file given with -config or CONFIG_FILE, the environment and
+// the flags in args, and validates it
+func Load(name string, args []string) (*Config, error) {
+	c := Default()
+	leaves := settings(c)
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	file := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML configuration file")
+	overrides := map[string]string{}
+	for _, leaf := range leaves {
+		path := leaf.path
+		usage := fmt.Sprintf("%s (env %s)", leaf.field.Tag.Get("usage"), leaf.field.Tag.Get("env"))
+		// Boolean flags may be given without a value, e.g. -features.graphiql
+		register := flags.Func
+		if leaf.value.Kind() == reflect.Bool {
+			register = flags.BoolFunc
+		}
+		register(path, usage, func(value string) error {
+			overrides[path] = value
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	if *file != "" {
+		if err := c.readFile(*file); err != nil {
+			return nil, err
+		}
+	}
+	for _, leaf := range leaves {
+		if value, ok := os.LookupEnv(leaf.field.Tag.Get("env")); ok {
+			if err := leaf.set(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, leaf := range leaves {
+		if value, ok := overrides[leaf.path]; ok {
+			if err := leaf.set(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%v", err)
+	}
+	return c, nil
+}
+
+// readFile reads a YAML or TOML configuration file over the current settings, rejecting unknown settings
+func (c *Config) readFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read configuration file: %v", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(c)
+		if err == io.EOF {
+			err = nil
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(c)
+	default:
+		return fmt.Errorf("configuration file %s must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("could not parse configuration file %s: %v", path, err)
+	}
+	return nil
+}
+
+// Print writes the configuration as YAML, with secrets redacted
+func (c *Config) Print(w io.Writer) error {
+	printed := *c
+	for _, leaf := range settings(&printed) {
+		if leaf.field.Tag.Get("secret") == "true" && leaf.value.String() != "" {
+			leaf.value.SetString(redacted)
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&printed); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes a configuration file with the given name to a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeConfigFile(t, "config.yaml", `
+server:
+  addr: ":4000"
+storage:
+  path: /file
+cache:
+  refresh_interval: 1m
+`)
+	t.Setenv("LISTEN_ADDR", ":5000")
+	t.Setenv("STORAGE_PATH", "/env")
+
+	c, err := Load("test", []string{"-config", file, "-server.addr", ":6000"})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.Server.Addr != ":6000" {
+		t.Errorf("server.addr = %q, want the flag value", c.Server.Addr)
+	}
+	if c.Storage.Path != "/env" {
+		t.Errorf("storage.path = %q, want the environment value", c.Storage.Path)
+	}
+	if c.Cache.RefreshInterval != Duration(time.Minute) {
+		t.Errorf("cache.refresh_interval = %v, want the file value", time.Duration(c.Cache.RefreshInterval))
+	}
+	if c.Providers.Timeout != Default().Providers.Timeout {
+		t.Errorf("providers.timeout = %v, want the default", time.Duration(c.Providers.Timeout))
+	}
+}
+
+func TestLoadBoolFlagWithoutValue(t *testing.T) {
+	file := writeConfigFile(t, "config.toml", `
+[features]
+graphiql = false
+`)
+
+	c, err := Load("test", []string{"-config", file, "-features.graphiql", "-features.alerts=false"})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !c.Features.GraphiQL {
+		t.Error("features.graphiql is off, want a flag without a value to turn it on")
+	}
+	if c.Features.Alerts {
+		t.Error("features.alerts is on, want -features.alerts=false to turn it off")
+	}
+}
+
+func TestLoadEnvironmentValues(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("SHUTDOWN_DELAY", "2s")
+	t.Setenv("CORS_ORIGINS", "https://a.example, https://b.example,")
+
+	c, err := Load("test", nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.Server.ShutdownDelay != Duration(2*time.Second) {
+		t.Errorf("server.shutdown_delay = %v, want 2s", time.Duration(c.Server.ShutdownDelay))
+	}
+	if got := strings.Join(c.Server.CORSOrigins, " "); got != "https://a.example https://b.example" {
+		t.Errorf("server.cors_origins = %q, want both origins", c.Server.CORSOrigins)
+	}
+
+	t.Setenv("SHUTDOWN_DELAY", "soon")
+	if _, err := Load("test", nil); err == nil || !strings.Contains(err.Error(), "server.shutdown_delay") {
+		t.Errorf("Load with an invalid duration returned %v, want an error naming the setting", err)
+	}
+}
+
+func TestLoadRejectsUnknownSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"config.yaml", "server:\n  adress: \":4000\"\n"},
+		{"config.toml", "[server]\nadress = \":4000\"\n"},
+	}
+	for _, test := range tests {
+		file := writeConfigFile(t, test.name, test.content)
+		if _, err := Load("test", []string{"-config", file}); err == nil {
+			t.Errorf("%s with an unknown setting was loaded", test.name)
+		}
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	c := Default()
+	c.Providers.APIKey = "api-secret"
+	c.Alerts.SMTPPassword = "smtp-secret"
+
+	var printed strings.Builder
+	if err := c.Print(&printed); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	for _, secret := range []string{"api-secret", "smtp-secret"} {
+		if strings.Contains(printed.String(), secret) {
+			t.Errorf("printed configuration holds %s:\n%s", secret, printed.String())
+		}
+	}
+	if strings.Count(printed.String(), redacted) != 2 {
+		t.Errorf("printed configuration does not redact both secrets:\n%s", printed.String())
+	}
+	if c.Providers.APIKey != "api-secret" {
+		t.Error("Print changed the configuration")
+	}
+}
@@ -0,0 +1,146 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// service is a background service, stopped by cancelling its context
+type service struct {
+	name   string
+	run    func(ctx context.Context)
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Lifecycle runs an HTTP server and background services, and on SIGINT or SIGTERM shuts them down in order:
+// readiness fails first, then the server drains in-flight requests, then services stop in the reverse order they
+// were added, each finishing its current work. A second signal exits immediately.
+type Lifecycle struct {
+	// ShutdownDelay is how long readiness fails before the server stops accepting requests, so load balancers
+	// stop routing to it
+	ShutdownDelay time.Duration
+	// DrainTimeout bounds the whole shutdown after the delay
+	DrainTimeout time.Duration
+
+	ready    atomic.Bool
+	draining chan struct{}
+	services []*service
+}
+
+// New creates a Lifecycle
+func New(shutdownDelay, drainTimeout time.Duration) *Lifecycle {
+	return &Lifecycle{
+		ShutdownDelay: shutdownDelay,
+		DrainTimeout:  drainTimeout,
+		draining:      make(chan struct{}),
+	}
+}
+
+// Go adds a background service, started by Run
+func (l *Lifecycle) Go(name string, run func(ctx context.Context)) {
+	l.services = append(l.services, &service{name: name, run: run, done: make(chan struct{})})
+}
+
+// Ready reports whether the server is serving and not draining
+func (l *Lifecycle) Ready() bool {
+	return l.ready.Load()
+}
+
+// Draining is closed when shutdown starts, e.g. to end long-lived streams that would hold the drain
+func (l *Lifecycle) Draining() <-chan struct{} {
+	return l.draining
+}
+
+// Run listens on the server address, starts the services and serves HTTP until a signal arrives or the server
+// fails, then shuts down
+func (l *Lifecycle) Run(server *http.Server) error {
+	addr := server.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %v", addr, err)
+	}
+	return l.serve(server, listener)
+}
+
+// serve runs the services and the server on a listener, becoming ready only once the listener is open
+func (l *Lifecycle) serve(server *http.Server, listener net.Listener) error {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	for _, s := range l.services {
+		var ctx context.Context
+		ctx, s.cancel = context.WithCancel(context.Background())
+		go func(s *service, ctx context.Context) {
+			defer close(s.done)
+			s.run(ctx)
+		}(s, ctx)
+	}
+
+	failed := make(chan error, 1)
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			failed <- err
+		}
+	}()
+	l.ready.Store(true)
+
+	var serveErr error
+	select {
+	case sig := <-signals:
+		fmt.Printf("Received %s, shutting down.\n", sig)
+	case serveErr = <-failed:
+		fmt.Printf("Server failed, shutting down: %v\n", serveErr)
+	}
+
+	// Exit right away on a second signal
+	go func() {
+		sig := <-signals
+		fmt.Printf("Received %s again, exiting.\n", sig)
+		os.Exit(1)
+	}()
+
+	return errors.Join(serveErr, l.shutdown(server))
+}
+
+// shutdown fails readiness, drains the server and stops the services
+func (l *Lifecycle) shutdown(server *http.Server) error {
+	l.ready.Store(false)
+	time.Sleep(l.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.DrainTimeout)
+	defer cancel()
+
+	var errs []error
+	close(l.draining)
+	if err := server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("could not drain the HTTP server: %v", err))
+	}
+
+	for i := len(l.services) - 1; i >= 0; i-- {
+		s := l.services[i]
+		if s.cancel == nil {
+			continue
+		}
+		s.cancel()
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("%s did not stop within the drain timeout", s.name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
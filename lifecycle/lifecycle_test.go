@@ -0,0 +1,107 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShutdownOrder(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	l := New(delay, 5*time.Second)
+
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	for _, name := range []string{"first", "second"} {
+		name := name
+		l.Go(name, func(ctx context.Context) {
+			<-ctx.Done()
+			record(name + " stopped")
+		})
+	}
+
+	// The request is in flight when draining starts and must finish before the services stop
+	started := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-l.Draining()
+		time.Sleep(20 * time.Millisecond)
+		record("request done")
+	})}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped := make(chan error, 1)
+	go func() { stopped <- l.serve(server, listener) }()
+
+	for !l.Ready() {
+		time.Sleep(time.Millisecond)
+	}
+	go http.Get("http://" + listener.Addr().String())
+	<-started
+
+	signaled := time.Now()
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	for l.Ready() {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-l.Draining():
+		t.Error("server drained before readiness had failed for the shutdown delay")
+	default:
+	}
+
+	<-l.Draining()
+	if elapsed := time.Since(signaled); elapsed < delay {
+		t.Errorf("server drained %v after the signal, want at least the %v shutdown delay", elapsed, delay)
+	}
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Errorf("serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not finish")
+	}
+
+	want := []string{"request done", "second stopped", "first stopped"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("shutdown events = %v, want %v", events, want)
+	}
+}
+
+func TestRunFailsWithoutListening(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+
+	l := New(0, time.Second)
+	started := false
+	l.Go("service", func(ctx context.Context) { started = true })
+
+	if err := l.Run(&http.Server{Addr: taken.Addr().String()}); err == nil {
+		t.Fatal("Run succeeded on an address in use")
+	}
+	if l.Ready() || started {
+		t.Errorf("ready = %v and service started = %v after listening failed, want neither", l.Ready(), started)
+	}
+}
@@ -3,42 +3,286 @@ package main
 
 import (
     "context"
+    "fmt"
+    "net/http"
+    "os"
+    "time"
     "github.com/gin-gonic/gin"
     "github.com/gin-contrib/cors"
     "github.com/graphql-go/handler"
+    "github.com/chafikchaban/greenheat-backend/config"
+    "github.com/chafikchaban/greenheat-backend/lifecycle"
+    "github.com/chafikchaban/greenheat-backend/metrics"
     "github.com/chafikchaban/greenheat-backend/weather"
   )
 
+// runSchemaCommand handles "schema print", which prints the SDL the API is served with, and "schema check
+// <previous.graphql>", which lists the breaking changes from a previous SDL and fails when there are any
+func runSchemaCommand(args []string) int {
+    if len(args) == 0 || (args[0] != "print" && args[0] != "check") || (args[0] == "check" && len(args) < 2) {
+        fmt.Fprintln(os.Stderr, "usage: schema print | schema check <previous.graphql>")
+        return 2
+    }
+    if args[0] == "print" {
+        fmt.Print(weather.SDL)
+        return 0
+    }
+
+    previous, err := os.ReadFile(args[1])
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Could not read %s: %v\n", args[1], err)
+        return 1
+    }
+
+    changes, err := weather.BreakingChanges(string(previous), weather.SDL)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        return 1
+    }
+    for _, change := range changes {
+        fmt.Printf("breaking: %s\n", change)
+    }
+    if len(changes) > 0 {
+        return 1
+    }
+    fmt.Println("No breaking changes")
+    return 0
+}
+
+// runConfigCommand handles "config print [flags]", which prints the configuration the server would run with
+func runConfigCommand(args []string) int {
+    if len(args) == 0 || args[0] != "print" {
+        fmt.Fprintln(os.Stderr, "usage: config print [flags]")
+        return 2
+    }
+    cfg, err := config.Load("config print", args[1:])
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        return 1
+    }
+    if err := cfg.Print(os.Stdout); err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        return 1
+    }
+    return 0
+}
+
+// corsConfig allows the configured origins, or any origin when they include "*"
+func corsConfig(origins []string) cors.Config {
+    corsConfig := cors.DefaultConfig()
+    for _, origin := range origins {
+        if origin == "*" {
+            corsConfig.AllowAllOrigins = true
+            return corsConfig
+        }
+    }
+    corsConfig.AllowOrigins = origins
+    return corsConfig
+}
+
 func main() {
+    if len(os.Args) > 1 && os.Args[1] == "schema" {
+        os.Exit(runSchemaCommand(os.Args[2:]))
+    }
+    if len(os.Args) > 1 && os.Args[1] == "config" {
+        os.Exit(runConfigCommand(os.Args[2:]))
+    }
+
+    cfg, err := config.Load(os.Args[0], os.Args[1:])
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(2)
+    }
+
+    // Background services start with the server and stop after it drained, in reverse order
+    life := lifecycle.New(time.Duration(cfg.Server.ShutdownDelay), time.Duration(cfg.Server.DrainTimeout))
+
     r := gin.Default()
-    r.Use(cors.Default())
+    r.Use(cors.New(corsConfig(cfg.Server.CORSOrigins)))
+    r.Use(metrics.Middleware())
+
+    refreshInterval := time.Duration(cfg.Cache.RefreshInterval)
 
     lc := weather.LocationController{}
-    wc := weather.WeatherController{}
-	db := weather.BootstrapDatabase("./")
+    wc := weather.WeatherController{
+        SnapshotMaxAge: cfg.SnapshotMaxAge(),
+        ForecastHistoryRetention: time.Duration(cfg.Cache.ForecastHistoryRetention),
+        ForecastURL: cfg.Providers.ForecastURL,
+        APIKey: cfg.Providers.APIKey,
+    }
+	db := weather.BootstrapDatabase(cfg.Storage.Path)
+
+    // Every request to Open-Meteo goes through one client so a stalled upstream can't hold a refresh forever
+    upstreamClient := &http.Client{Timeout: time.Duration(cfg.Providers.Timeout)}
+    weather.ForecastUpstream.Client = upstreamClient
+    weather.ArchiveUpstream.Client = upstreamClient
+
+    // Observed weather comes from Open-Meteo, or from canned responses when a fixture directory is set
+    ac := weather.ArchiveController{
+        Provider:   &weather.OpenMeteoArchive{BaseURL: cfg.Providers.ArchiveURL, APIKey: cfg.Providers.APIKey},
+        SettleDays: 7,
+    }
+    if dir := cfg.Providers.ArchiveFixtureDir; dir != "" {
+        ac.Provider = &weather.FixtureArchive{Dir: dir}
+    }
+
+    // Post location changes and refreshed forecasts to subscribed webhooks
+    wh := weather.NewWebhookController(db, 256)
+    wh.DrainTimeout = time.Duration(cfg.Server.DrainTimeout)
+    if cfg.Features.Webhooks {
+        lc.OnChange = func(event string, location weather.Location) {
+            wh.Emit(event, location)
+        }
+        wc.OnForecastFetched = func(info *weather.WeatherForecastInfo) {
+            wh.Emit(weather.EventForecastRefreshed, info)
+        }
+        life.Go("webhook deliveries", wh.Run)
+    }
+
+    // Readiness waits for the initial locations to be stored, so the server stays unready when that fails
+    health := &weather.HealthController{DB: db, StartedAt: time.Now()}
+    if err := lc.InitializeLocations(db); err != nil {
+        fmt.Fprintln(os.Stderr, err)
+    } else {
+        health.MarkSeeded()
+    }
+
+    // Refresh every location in the background and push current weather changes to subscribers
+    sc := weather.NewSubscriptionController(16)
+    scheduler := weather.NewScheduler(db, lc, wc, refreshInterval)
+    scheduler.OnCurrent = func(info *weather.CurrentWeatherInfo) {
+        sc.Publish(info)
+    }
+    life.Go("weather refreshes", scheduler.Run)
+    health.Providers = []weather.StatusProvider{db, weather.ForecastUpstream, weather.ArchiveUpstream, weather.SnapshotCache, scheduler}
 
-    lc.InitializeLocations(db)
+    // Compare stored forecasts with observed weather once a day
+    vc := weather.VerificationController{}
+    if cfg.Features.Verification {
+        life.Go("forecast verification", func(ctx context.Context) {
+            vc.RunVerifier(ctx, db, lc, wc, ac, time.Duration(cfg.Verification.Interval))
+        })
+    }
+
+    // Evaluate alert rules against the latest forecasts. Alert emails go to the outbox directory unless an SMTP server is set.
+    var mailer weather.Notifier = &weather.OutboxNotifier{Dir: cfg.Alerts.OutboxDir, From: cfg.Alerts.EmailFrom}
+    if cfg.Alerts.SMTPAddr != "" {
+        mailer = &weather.SMTPNotifier{
+            Addr:     cfg.Alerts.SMTPAddr,
+            From:     cfg.Alerts.EmailFrom,
+            Username: cfg.Alerts.SMTPUsername,
+            Password: cfg.Alerts.SMTPPassword,
+        }
+    }
+    al := weather.AlertController{
+        Notifiers: map[string]weather.Notifier{
+            weather.ChannelWebhook: &weather.WebhookNotifier{},
+            weather.ChannelEmail:   mailer,
+            weather.ChannelFeed:    &weather.FeedNotifier{},
+        },
+    }
+    if cfg.Features.Alerts {
+        life.Go("alert evaluation", func(ctx context.Context) {
+            al.RunEvaluator(ctx, db, lc, wc, cfg.AlertInterval())
+        })
+    }
+
+    dc := weather.DegreeDayController{}
+    pv := weather.SolarController{}
+    wp := weather.WindController{}
+    hc := weather.HeatDemandController{}
+    gc := weather.GreenWindowController{}
+
+    // Every route and transport executes operations with the same services
+    app := &weather.App{
+        DB:            db,
+        Locations:     lc,
+        Weather:       wc,
+        Subscriptions: sc,
+        Archive:       ac,
+        Verification:  vc,
+        DegreeDays:    dc,
+        Solar:         pv,
+        Wind:          wp,
+        HeatDemand:    hc,
+        GreenWindows:  gc,
+        Alerts:        al,
+        Webhooks:      wh,
+    }
+    withApp := func(ctx context.Context) context.Context {
+        return weather.WithApp(ctx, app)
+    }
+
+    // Count and time operations and resolvers of every route and transport
+    weather.Schema.AddExtensions(weather.MetricsExtension{})
 
     // Create GraphQL handler
     h := handler.New(&handler.Config{
         Schema:   &weather.Schema,
         Pretty:   true,
-        GraphiQL: true,
+        GraphiQL: cfg.Features.GraphiQL,
     })
 
     // GraphQL endpoint
     r.POST("/graphql", func(c *gin.Context) {
-        ctx := c.Request.Context()
-        ctx = context.WithValue(ctx, "db", db)
-        ctx = context.WithValue(ctx, "lc", lc)
-        ctx = context.WithValue(ctx, "wc", wc)
-        h.ContextHandler(ctx, c.Writer, c.Request)
+        h.ContextHandler(withApp(c.Request.Context()), c.Writer, c.Request)
+    })
+
+    // Subscription endpoints, over WebSocket (graphql-transport-ws) with an SSE fallback
+    if cfg.Features.Subscriptions {
+        ss := &weather.SubscriptionServer{
+            Schema:  &weather.Schema,
+            Context: withApp,
+            Done:    life.Draining(),
+        }
+        r.GET("/graphql/ws", gin.WrapF(ss.ServeWebSocket))
+        r.GET("/graphql/sse", gin.WrapF(ss.ServeSSE))
+        r.POST("/graphql/sse", gin.WrapF(ss.ServeSSE))
+    }
+
+    // SDL of the API, for clients and code generators
+    r.GET("/schema.graphql", func(c *gin.Context) {
+        c.Data(200, "text/plain; charset=utf-8", []byte(weather.SDL))
     })
 
     // GraphiQL endpoint for testing
-    r.GET("/graphiql", func(c *gin.Context) {
-        h.ContextHandler(c.Request.Context(), c.Writer, c.Request)
+    if cfg.Features.GraphiQL {
+        r.GET("/graphiql", func(c *gin.Context) {
+            h.ContextHandler(withApp(c.Request.Context()), c.Writer, c.Request)
+        })
+    }
+
+    // Liveness only needs the process to serve requests
+    r.GET("/healthz", func(c *gin.Context) {
+        c.String(200, "ok")
+    })
+
+    // Readiness fails while the server starts and drains, storage is not writable or locations are not seeded
+    r.GET("/readyz", func(c *gin.Context) {
+        if !life.Ready() {
+            c.String(503, "not ready: draining")
+            return
+        }
+        if err := health.Readiness(); err != nil {
+            c.String(503, "not ready: %v", err)
+            return
+        }
+        c.String(200, "ok")
     })
 
-    r.Run(":3000") 
-}
\ No newline at end of file
+    // Health of every dependency, for operators
+    r.GET("/status", func(c *gin.Context) {
+        c.JSON(200, health.Status())
+    })
+
+    // Prometheus metrics of HTTP requests, GraphQL operations, upstream calls, the cache, storage and locations
+    weather.RegisterLocationGauges(metrics.Default, db, lc, wc)
+    r.GET("/metrics", gin.WrapH(metrics.Default))
+
+    // Scribble writes every record synchronously, so storage is consistent once requests and background services stopped
+    server := &http.Server{Addr: cfg.Server.Addr, Handler: r}
+    if err := life.Run(server); err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+}
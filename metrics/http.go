@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTP metrics, by route rather than path so IDs in paths don't create a series each
+var (
+	httpRequests = Default.NewCounterVec("greenheat_http_requests_total",
+		"HTTP requests served, by method, route and status code", "method", "route", "code")
+	httpDuration = Default.NewHistogramVec("greenheat_http_request_duration_seconds",
+		"Time taken to serve HTTP requests, by method and route", DefaultBuckets, "method", "route")
+)
+
+// Middleware counts and times the requests served by a gin engine. Requests matching no route are counted
+// under the route "unmatched".
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		httpRequests.Inc(method, route, strconv.Itoa(c.Writer.Status()))
+		httpDuration.Observe(time.Since(start).Seconds(), method, route)
+	}
+}
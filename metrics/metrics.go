@@ -0,0 +1,251 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of latency histograms
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// metric is written in the Prometheus text format
+type metric interface {
+	write(w io.Writer)
+}
+
+// Registry holds metrics and serves them in the Prometheus text format
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+// Default is the registry served by /metrics
+var Default = &Registry{}
+
+// register adds a metric to the registry
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// Write writes every metric in the Prometheus text format, in the order they were created
+func (r *Registry) Write(w io.Writer) {
+	r.mu.Lock()
+	metrics := append([]metric(nil), r.metrics...)
+	r.mu.Unlock()
+
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// ServeHTTP serves the metrics to Prometheus scrapes
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.Write(w)
+}
+
+// vec is the series of a metric, keyed by their label values
+type vec[T any] struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*T
+	values map[string][]string
+}
+
+func newVec[T any](name, help string, labels []string) vec[T] {
+	return vec[T]{name: name, help: help, labels: labels, series: map[string]*T{}, values: map[string][]string{}}
+}
+
+// with returns the series of the label values, created by create the first time
+func (v *vec[T]) with(values []string, create func() *T) *T {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s takes %d label values, got %d", v.name, len(v.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	series, ok := v.series[key]
+	if !ok {
+		series = create()
+		v.series[key] = series
+		v.values[key] = append([]string(nil), values...)
+	}
+	return series
+}
+
+// each calls fn with the label pairs of every series, sorted by label values
+func (v *vec[T]) each(fn func(labels string, series *T)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, key := range sortedKeys(v.series) {
+		fn(labelPairs(v.labels, v.values[key]), v.series[key])
+	}
+}
+
+// header writes the HELP and TYPE lines of a metric
+func header(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help), name, kind)
+}
+
+// CounterVec is a counter partitioned by labels
+type CounterVec struct {
+	vec[counter]
+}
+
+type counter struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// NewCounterVec creates and registers a counter with the given labels
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{newVec[counter](name, help, labels)}
+	r.register(c)
+	return c
+}
+
+// Add adds a value to the series of the label values
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	series := c.with(labelValues, func() *counter { return &counter{} })
+	series.mu.Lock()
+	defer series.mu.Unlock()
+	series.value += value
+}
+
+// Inc adds one to the series of the label values
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	header(w, c.name, c.help, "counter")
+	c.each(func(labels string, series *counter) {
+		series.mu.Lock()
+		defer series.mu.Unlock()
+		fmt.Fprintf(w, "%s%s %s\n", c.name, braces(labels), formatFloat(series.value))
+	})
+}
+
+// HistogramVec is a histogram partitioned by labels
+type HistogramVec struct {
+	vec[histogram]
+	buckets []float64
+}
+
+type histogram struct {
+	mu     sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec creates and registers a histogram with the given bucket upper bounds and labels
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{vec: newVec[histogram](name, help, labels), buckets: buckets}
+	r.register(h)
+	return h
+}
+
+// Observe records a value in the series of the label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	series := h.with(labelValues, func() *histogram { return &histogram{counts: make([]uint64, len(h.buckets))} })
+	series.mu.Lock()
+	defer series.mu.Unlock()
+	for i, bound := range h.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	header(w, h.name, h.help, "histogram")
+	h.each(func(labels string, series *histogram) {
+		series.mu.Lock()
+		defer series.mu.Unlock()
+		prefix := labels
+		if prefix != "" {
+			prefix += ","
+		}
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket{%sle=\"%s\"} %d\n", h.name, prefix, formatFloat(bound), series.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", h.name, prefix, series.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, braces(labels), formatFloat(series.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, braces(labels), series.count)
+	})
+}
+
+// GaugeFunc is a gauge whose value is read when metrics are scraped
+type GaugeFunc struct {
+	name  string
+	help  string
+	value func() float64
+}
+
+// NewGaugeFunc creates and registers a gauge reading its value from a function on every scrape
+func (r *Registry) NewGaugeFunc(name, help string, value func() float64) *GaugeFunc {
+	g := &GaugeFunc{name: name, help: help, value: value}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) write(w io.Writer) {
+	header(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.value()))
+}
+
+// labelPairs formats label names and values as name="value" pairs, escaping the values
+func labelPairs(names, values []string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=\"%s\"", name, escaper.Replace(values[i]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// braces wraps label pairs in braces, leaving series without labels bare
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+// formatFloat formats a sample value as Prometheus expects it
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// sortedKeys returns the keys of a map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
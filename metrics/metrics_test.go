@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCounterVecWrite(t *testing.T) {
+	r := &Registry{}
+	c := r.NewCounterVec("requests_total", "Requests served", "method", "code")
+	c.Inc("POST", "200")
+	c.Inc("GET", "200")
+	c.Add(2.5, "GET", "200")
+	c.Inc("GET", "500")
+
+	want := `# HELP requests_total Requests served
+# TYPE requests_total counter
+requests_total{method="GET",code="200"} 3.5
+requests_total{method="GET",code="500"} 1
+requests_total{method="POST",code="200"} 1
+`
+	if got := write(r); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCounterVecWithoutLabels(t *testing.T) {
+	r := &Registry{}
+	r.NewCounterVec("events_total", "Events").Inc()
+
+	want := `# HELP events_total Events
+# TYPE events_total counter
+events_total 1
+`
+	if got := write(r); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestHistogramVecWrite(t *testing.T) {
+	r := &Registry{}
+	h := r.NewHistogramVec("duration_seconds", "Time taken", []float64{0.1, 1}, "route")
+	h.Observe(0.05, "/graphql")
+	h.Observe(0.1, "/graphql")
+	h.Observe(0.5, "/graphql")
+	h.Observe(3, "/graphql")
+
+	// Buckets are cumulative and their upper bounds inclusive
+	want := `# HELP duration_seconds Time taken
+# TYPE duration_seconds histogram
+duration_seconds_bucket{route="/graphql",le="0.1"} 2
+duration_seconds_bucket{route="/graphql",le="1"} 3
+duration_seconds_bucket{route="/graphql",le="+Inf"} 4
+duration_seconds_sum{route="/graphql"} 3.65
+duration_seconds_count{route="/graphql"} 4
+`
+	if got := write(r); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestHistogramVecWithoutLabels(t *testing.T) {
+	r := &Registry{}
+	r.NewHistogramVec("duration_seconds", "Time taken", []float64{1}).Observe(2)
+
+	want := `# HELP duration_seconds Time taken
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="1"} 0
+duration_seconds_bucket{le="+Inf"} 1
+duration_seconds_sum 2
+duration_seconds_count 1
+`
+	if got := write(r); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGaugeFuncWrite(t *testing.T) {
+	r := &Registry{}
+	value := 1.0
+	r.NewGaugeFunc("ratio", "Share of hits", func() float64 { return value })
+
+	if got, want := write(r), "# HELP ratio Share of hits\n# TYPE ratio gauge\nratio 1\n"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	// The value is read on every scrape
+	value = 0.25
+	if got := write(r); !strings.HasSuffix(got, "ratio 0.25\n") {
+		t.Errorf("got\n%s\nwant ratio 0.25", got)
+	}
+}
+
+func TestWriteEscapes(t *testing.T) {
+	r := &Registry{}
+	r.NewCounterVec("escaped_total", "Help with a \\ and\na new line", "value").Inc("a \"quoted\" \\ value\n")
+
+	want := `# HELP escaped_total Help with a \\ and\na new line
+# TYPE escaped_total counter
+escaped_total{value="a \"quoted\" \\ value\n"} 1
+`
+	if got := write(r); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteOrder(t *testing.T) {
+	r := &Registry{}
+	r.NewGaugeFunc("second", "Created first", func() float64 { return 0 })
+	r.NewCounterVec("first", "Created second")
+
+	got := write(r)
+	if strings.Index(got, "# HELP second") > strings.Index(got, "# HELP first") {
+		t.Errorf("metrics are not written in the order they were created:\n%s", got)
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "0"},
+		{42, "42"},
+		{0.005, "0.005"},
+		{1e21, "1e+21"},
+		{math.Inf(1), "+Inf"},
+		{math.Inf(-1), "-Inf"},
+		{math.NaN(), "NaN"},
+	}
+	for _, test := range tests {
+		if got := formatFloat(test.value); got != test.want {
+			t.Errorf("formatFloat(%v) = %s, want %s", test.value, got, test.want)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	r := &Registry{}
+	r.NewCounterVec("events_total", "Events").Inc()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if got := w.Header().Get("Content-Type"); got != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("Content-Type = %s", got)
+	}
+	if got := w.Body.String(); got != write(r) {
+		t.Errorf("body = %s", got)
+	}
+}
+
+func TestLabelValueCount(t *testing.T) {
+	r := &Registry{}
+	c := r.NewCounterVec("requests_total", "Requests served", "method", "code")
+	defer func() {
+		if recover() == nil {
+			t.Error("a series with missing label values was created")
+		}
+	}()
+	c.Inc("GET")
+}
+
+// write returns what the registry serves
+func write(r *Registry) string {
+	var b strings.Builder
+	r.Write(&b)
+	return b.String()
+}
@@ -0,0 +1,175 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Alert rule defaults
+const (
+	DefaultAlertHorizonHours    = 48
+	DefaultAlertCooldownMinutes = 6 * 60
+)
+
+// Alert notification channels
+const (
+	ChannelWebhook = "webhook"
+	ChannelEmail   = "email"
+	ChannelFeed    = "feed"
+)
+
+// hourlyTimeLayout is the layout of the local times of hourly forecasts
+const hourlyTimeLayout = "2006-01-02T15:04"
+
+// alertOperators compare a forecast value with a rule threshold
+var alertOperators = map[string]func(value, threshold float64) bool{
+	"<":  func(value, threshold float64) bool { return value < threshold },
+	"<=": func(value, threshold float64) bool { return value <= threshold },
+	">":  func(value, threshold float64) bool { return value > threshold },
+	">=": func(value, threshold float64) bool { return value >= threshold },
+	"==": func(value, threshold float64) bool { return value == threshold },
+}
+
+// validate checks an alert rule can be evaluated and delivered, and fills in its defaults
+func (rule *AlertRule) validate() error {
+	if rule.HorizonHours == 0 {
+		rule.HorizonHours = DefaultAlertHorizonHours
+	}
+	if len(rule.Channels) == 0 {
+		rule.Channels = []string{ChannelFeed}
+	}
+
+	_, daily := DailyData{}.Series(rule.Metric)
+	_, hourly := HourlyData{}.Series(rule.Metric)
+	switch {
+	case rule.Name == "":
+		return fmt.Errorf("name is required")
+	case strings.ContainsAny(rule.Name, "\r\n"):
+		return fmt.Errorf("name must be a single line")
+	case !daily && !hourly:
+		return fmt.Errorf("unknown metric %q", rule.Metric)
+	case alertOperators[rule.Operator] == nil:
+		return fmt.Errorf("operator must be one of <, <=, >, >=, ==")
+	case rule.HorizonHours < 1 || rule.HorizonHours > 7*24:
+		return fmt.Errorf("horizon must be between 1 and %d hours", 7*24)
+	case rule.CooldownMinutes < 0:
+		return fmt.Errorf("cooldown must not be negative")
+	}
+
+	for _, channel := range rule.Channels {
+		switch channel {
+		case ChannelWebhook:
+			if target, err := url.Parse(rule.WebhookURL); err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+				return fmt.Errorf("webhook channel needs an http or https webhook URL")
+			}
+		case ChannelEmail:
+			address, err := mail.ParseAddress(rule.Email)
+			if err != nil {
+				return fmt.Errorf("email channel needs a valid email address")
+			}
+			// Only the address is kept, a display name would end up in the SMTP envelope
+			rule.Email = address.Address
+		case ChannelFeed:
+		default:
+			return fmt.Errorf("unknown channel %q", channel)
+		}
+	}
+	return nil
+}
+
+// watches reports whether the rule applies to a location
+func (rule *AlertRule) watches(locationID string) bool {
+	if len(rule.LocationIDs) == 0 {
+		return true
+	}
+	for _, id := range rule.LocationIDs {
+		if id == locationID {
+			return true
+		}
+	}
+	return false
+}
+
+// breach is the first forecast value meeting a rule
+type breach struct {
+	time  string
+	value float64
+	unit  string
+}
+
+// firstBreach looks for the first value of the forecast within the rule horizon that meets the rule.
+// Hourly values count from the current hour and daily values from the current day, and missing values are skipped.
+func (rule *AlertRule) firstBreach(forecast *WeatherForecastInfo, now time.Time) (*breach, bool) {
+	zone := time.FixedZone("", forecast.UtcOffsetSeconds)
+	now = now.In(zone)
+	end := now.Add(time.Duration(rule.HorizonHours) * time.Hour)
+	compare := alertOperators[rule.Operator]
+
+	if values, ok := forecast.Hourly.Series(rule.Metric); ok {
+		for i, value := range values {
+			if i >= len(forecast.Hourly.Time) {
+				break
+			}
+			at, err := time.ParseInLocation(hourlyTimeLayout, forecast.Hourly.Time[i], zone)
+			if err != nil || math.IsNaN(value) || at.Before(now.Truncate(time.Hour)) || !at.Before(end) {
+				continue
+			}
+			if compare(value, rule.Threshold) {
+				return &breach{time: forecast.Hourly.Time[i], value: value, unit: forecast.HourlyUnits.Unit(rule.Metric)}, true
+			}
+		}
+		return nil, false
+	}
+
+	values, _ := forecast.Daily.Series(rule.Metric)
+	for i, value := range values {
+		if i >= len(forecast.Daily.Time) {
+			break
+		}
+		day, err := time.ParseInLocation(dateLayout, forecast.Daily.Time[i], zone)
+		if err != nil || math.IsNaN(value) || !day.AddDate(0, 0, 1).After(now) || !day.Before(end) {
+			continue
+		}
+		if compare(value, rule.Threshold) {
+			return &breach{time: forecast.Daily.Time[i], value: value, unit: forecast.DailyUnits.Unit(rule.Metric)}, true
+		}
+	}
+	return nil, false
+}
+
+// shouldNotify reports whether a breach is new and the rule is out of its cooldown
+func (state *AlertState) shouldNotify(rule *AlertRule, b *breach, now time.Time) bool {
+	for _, notified := range state.Notified {
+		if notified == b.time {
+			return false
+		}
+	}
+	cooldown := time.Duration(rule.CooldownMinutes) * time.Minute
+	return state.LastTriggeredAt.IsZero() || now.Sub(state.LastTriggeredAt) >= cooldown
+}
+
+// remember records a notified breach, keeping only the forecast times that can still come up
+func (state *AlertState) remember(b *breach, now time.Time) {
+	state.LastTriggeredAt = now
+	state.Notified = append(state.Notified, b.time)
+
+	cutoff := now.AddDate(0, 0, -1).Format(dateLayout)
+	kept := state.Notified[:0]
+	for _, notified := range state.Notified {
+		// Local dates and hours sort like strings, so older entries compare lower than the cutoff date
+		if notified >= cutoff {
+			kept = append(kept, notified)
+		}
+	}
+	state.Notified = kept
+}
+
+// message describes an alert for humans
+func (alert *Alert) message() string {
+	return fmt.Sprintf("%s: %s is forecast at %g %s (%s %g) in %s on %s",
+		alert.RuleName, alert.Metric, alert.Value, alert.Unit, alert.Operator, alert.Threshold, alert.LocationName, alert.ForecastTime)
+}
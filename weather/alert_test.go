@@ -0,0 +1,142 @@
+package weather
+
+import (
+	"bytes"
+	"math"
+	"mime"
+	"net/mail"
+	"testing"
+	"time"
+)
+
+// alertForecast is a forecast at UTC issued on 2024-05-01, missing the 10:00 temperature and the minimum of 2024-05-02
+func alertForecast() *WeatherForecastInfo {
+	return &WeatherForecastInfo{
+		ID: "52.5200_13.4050",
+		Hourly: HourlyData{
+			Time:          []string{"2024-05-01T09:00", "2024-05-01T10:00", "2024-05-01T11:00", "2024-05-01T12:00", "2024-05-01T13:00"},
+			Temperature2m: []float64{25, math.NaN(), 15, 21, 30},
+		},
+		HourlyUnits: Units{Temperature2m: "°C"},
+		Daily: DailyData{
+			Time:             []string{"2024-04-30", "2024-05-01", "2024-05-02", "2024-05-03"},
+			Temperature2mMin: []float64{-5, 3, math.NaN(), -8},
+		},
+		DailyUnits: Units{Temperature2mMin: "°C"},
+	}
+}
+
+func TestFirstBreach(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		rule     AlertRule
+		wantTime string
+		wantOK   bool
+	}{
+		{
+			// 09:00 is before the current hour
+			name:     "hourly breach within the horizon",
+			rule:     AlertRule{Metric: "temperature_2m", Operator: ">", Threshold: 20, HorizonHours: 2},
+			wantTime: "2024-05-01T12:00",
+			wantOK:   true,
+		},
+		{
+			name: "hourly breach past the horizon",
+			rule: AlertRule{Metric: "temperature_2m", Operator: ">", Threshold: 20, HorizonHours: 1},
+		},
+		{
+			name: "missing hourly value",
+			rule: AlertRule{Metric: "temperature_2m", Operator: "<", Threshold: 10, HorizonHours: 4},
+		},
+		{
+			// 2024-04-30 is over and 2024-05-02 is missing
+			name:     "daily breach within the horizon",
+			rule:     AlertRule{Metric: "temperature_2m_min", Operator: "<", Threshold: 0, HorizonHours: 48},
+			wantTime: "2024-05-03",
+			wantOK:   true,
+		},
+		{
+			name: "daily breach past the horizon",
+			rule: AlertRule{Metric: "temperature_2m_min", Operator: "<", Threshold: 0, HorizonHours: 24},
+		},
+		{
+			name:     "current day counts",
+			rule:     AlertRule{Metric: "temperature_2m_min", Operator: ">=", Threshold: 3, HorizonHours: 1},
+			wantTime: "2024-05-01",
+			wantOK:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, ok := test.rule.firstBreach(alertForecast(), now)
+			if ok != test.wantOK {
+				t.Fatalf("firstBreach found a breach: %v, want %v", ok, test.wantOK)
+			}
+			if ok && (b.time != test.wantTime || b.unit != "°C") {
+				t.Errorf("firstBreach = %+v, want a breach at %s in °C", b, test.wantTime)
+			}
+		})
+	}
+}
+
+func TestAlertStateDeduplicatesAndCoolsDown(t *testing.T) {
+	rule := &AlertRule{CooldownMinutes: 60}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	state := &AlertState{}
+
+	first := &breach{time: "2024-05-01T12:00"}
+	if !state.shouldNotify(rule, first, now) {
+		t.Fatal("first breach is not notified")
+	}
+	state.remember(first, now)
+
+	tests := []struct {
+		name string
+		b    *breach
+		at   time.Time
+		want bool
+	}{
+		{"same forecast time", first, now.Add(2 * time.Hour), false},
+		{"within the cooldown", &breach{time: "2024-05-01T13:00"}, now.Add(59 * time.Minute), false},
+		{"after the cooldown", &breach{time: "2024-05-01T13:00"}, now.Add(time.Hour), true},
+	}
+	for _, test := range tests {
+		if got := state.shouldNotify(rule, test.b, test.at); got != test.want {
+			t.Errorf("%s: shouldNotify = %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	// Forecast times more than a day old can't come up again
+	state.remember(&breach{time: "2024-05-03"}, now.AddDate(0, 0, 2))
+	if len(state.Notified) != 1 || state.Notified[0] != "2024-05-03" {
+		t.Errorf("notified = %v, want only 2024-05-03", state.Notified)
+	}
+}
+
+func TestEmailMessageCannotInjectHeaders(t *testing.T) {
+	alert := &Alert{
+		RuleName:     "Frost\r\nBcc: victim@example.com",
+		LocationName: "Berlin\nX-Injected: yes",
+		Message:      "Frost is forecast",
+		TriggeredAt:  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(emailMessage("alerts@example.com", "user@example.com", alert)))
+	if err != nil {
+		t.Fatalf("could not parse email: %v", err)
+	}
+	for _, header := range []string{"Bcc", "X-Injected"} {
+		if value := message.Header.Get(header); value != "" {
+			t.Errorf("email has an injected %s header: %q", header, value)
+		}
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("could not decode subject: %v", err)
+	}
+	if want := "[GreenHeat] " + alert.RuleName + " in " + alert.LocationName; subject != want {
+		t.Errorf("subject = %q, want %q", subject, want)
+	}
+}
@@ -0,0 +1,60 @@
+package weather
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoApp is returned by resolvers executed without an App in their context
+var ErrNoApp = errors.New("no app in the request context")
+
+// App holds the services the API is served with. It is wired once at startup and attached to the context of every
+// operation, whichever route or transport it comes from.
+type App struct {
+	DB            Database
+	Locations     LocationController
+	Weather       WeatherController
+	Subscriptions *SubscriptionController
+	Archive       ArchiveController
+	Verification  VerificationController
+	DegreeDays    DegreeDayController
+	Solar         SolarController
+	Wind          WindController
+	HeatDemand    HeatDemandController
+	GreenWindows  GreenWindowController
+	Alerts        AlertController
+	Webhooks      *WebhookController
+}
+
+// contextKey keys the values this package stores in contexts, so they can't collide with other packages' keys
+type contextKey int
+
+const (
+	appKey contextKey = iota
+	loaderKey
+	operationMetricsKey
+)
+
+// WithApp attaches an app to the context of an operation, along with a weather loader for that operation
+func WithApp(ctx context.Context, app *App) context.Context {
+	ctx = context.WithValue(ctx, appKey, app)
+	return context.WithValue(ctx, loaderKey, NewWeatherLoader(app.DB, app.Weather))
+}
+
+// AppFrom returns the app attached to a context
+func AppFrom(ctx context.Context) (*App, error) {
+	app, ok := ctx.Value(appKey).(*App)
+	if !ok || app == nil {
+		return nil, ErrNoApp
+	}
+	return app, nil
+}
+
+// loaderFrom returns the weather loader of the operation a context belongs to
+func loaderFrom(ctx context.Context) (*WeatherLoader, error) {
+	loader, ok := ctx.Value(loaderKey).(*WeatherLoader)
+	if !ok {
+		return nil, ErrNoApp
+	}
+	return loader, nil
+}
@@ -1,14 +1,21 @@
 package weather
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	scribble "github.com/nanobox-io/golang-scribble"
 )
 
 // Database is a wrapper of a database driver
 type Database struct {
-	d *scribble.Driver
+	d *timedDriver
 }
 
 // BootstrapDatabase bootstaps a database instance
@@ -16,6 +23,71 @@ func BootstrapDatabase(dir string) Database {
 	db, err := scribble.New(dir, nil)
 	if err != nil {
 		fmt.Println("Error", err)
+		return Database{}
 	}
-	return Database{db}
+	return Database{&timedDriver{db, dir}}
+}
+
+// timedDriver is a scribble driver timing every operation by collection
+type timedDriver struct {
+	driver *scribble.Driver
+	dir    string
+}
+
+// observe records how long an operation on a collection took. Per-location collections such as archive/<id>/daily
+// are recorded under their root so locations don't create a series each.
+func observe(operation, collection string, start time.Time) {
+	collection, _, _ = strings.Cut(collection, "/")
+	storageDuration.Observe(time.Since(start).Seconds(), operation, collection)
+}
+
+// Write stores a record in a collection
+func (t *timedDriver) Write(collection, resource string, v interface{}) error {
+	defer observe("write", collection, time.Now())
+	return t.driver.Write(collection, resource, v)
+}
+
+// Read reads a record of a collection into v
+func (t *timedDriver) Read(collection, resource string, v interface{}) error {
+	defer observe("read", collection, time.Now())
+	return t.driver.Read(collection, resource, v)
+}
+
+// ReadAll reads every record of a collection
+func (t *timedDriver) ReadAll(collection string) ([]string, error) {
+	defer observe("read_all", collection, time.Now())
+	return t.driver.ReadAll(collection)
+}
+
+// List returns the names of the records of a collection in ascending order, without reading them. A collection
+// that was never written is empty.
+func (t *timedDriver) List(collection string) ([]string, error) {
+	defer observe("list", collection, time.Now())
+	entries, err := os.ReadDir(filepath.Join(t.dir, collection))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Delete deletes a record, or a whole collection when resource is empty
+func (t *timedDriver) Delete(collection, resource string) error {
+	defer observe("delete", collection, time.Now())
+	return t.driver.Delete(collection, resource)
+}
+
+// isMissing reports whether scribble failed because the record or collection does not exist
+func isMissing(err error) bool {
+	return strings.HasPrefix(err.Error(), "Unable to find file or directory")
 }
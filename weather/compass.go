@@ -0,0 +1,61 @@
+package weather
+
+import "math"
+
+// compassPoints are the 16 points of the compass, clockwise from north
+var compassPoints = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// beaufortLimits are the upper wind speeds in m/s of Beaufort forces 0 to 11, force 12 lying above
+var beaufortLimits = []float64{0.5, 1.5, 3.3, 5.5, 7.9, 10.7, 13.8, 17.1, 20.7, 24.4, 28.4, 32.6}
+
+// CompassPoint returns the 16-point compass label of a direction in degrees
+func CompassPoint(direction float64) string {
+	direction = math.Mod(direction, 360)
+	if direction < 0 {
+		direction += 360
+	}
+	return compassPoints[int(math.Round(direction/22.5))%len(compassPoints)]
+}
+
+// Beaufort returns the Beaufort force of a wind speed in m/s
+func Beaufort(speed float64) int {
+	for force, limit := range beaufortLimits {
+		if speed < limit {
+			return force
+		}
+	}
+	return len(beaufortLimits)
+}
+
+// WindComponents splits a wind blowing from a direction in degrees into its eastward (u) and northward (v) components,
+// in the unit of the speed
+func WindComponents(speed, direction float64) (u float64, v float64) {
+	angle := radians(direction)
+	return round(-speed*math.Sin(angle), 2), round(-speed*math.Cos(angle), 2)
+}
+
+// windSpeedInMs converts a wind speed from an Open-Meteo unit label to m/s, an empty label standing for the km/h default
+func windSpeedInMs(speed float64, unit string) float64 {
+	if unit == "" {
+		unit = unitLabels[Kmh]
+	}
+	return metresPerSecond(speed, unit)
+}
+
+// windComponents splits the daily maximum wind speeds blowing from the dominant directions into u and v components,
+// NaN for days missing either
+func (d DailyData) windComponents() ([]float64, []float64) {
+	days := len(d.WindSpeed10mMax)
+	if len(d.WindDirectionAngle) < days {
+		days = len(d.WindDirectionAngle)
+	}
+	u, v := make([]float64, days), make([]float64, days)
+	for i := 0; i < days; i++ {
+		if d.WindDirectionAngle[i] == nil {
+			u[i], v[i] = math.NaN(), math.NaN()
+			continue
+		}
+		u[i], v[i] = WindComponents(d.WindSpeed10mMax[i], float64(*d.WindDirectionAngle[i]))
+	}
+	return u, v
+}
@@ -0,0 +1,101 @@
+package weather
+
+import "testing"
+
+func TestCompassPoint(t *testing.T) {
+	tests := []struct {
+		direction float64
+		want      string
+	}{
+		{0, "N"},
+		{360, "N"},
+		{720, "N"},
+		{-0.1, "N"},
+		{-90, "W"},
+		// N spans 348.75° to 11.25°, each point being 22.5° wide
+		{348.74, "NNW"},
+		{348.75, "N"},
+		{359.9, "N"},
+		{11.24, "N"},
+		{11.25, "NNE"},
+		{45, "NE"},
+		{90, "E"},
+		{135, "SE"},
+		{180, "S"},
+		{191.25, "SSW"},
+		{225, "SW"},
+		{270, "W"},
+		{290, "WNW"},
+		{315, "NW"},
+		{337.5, "NNW"},
+	}
+	for _, test := range tests {
+		if got := CompassPoint(test.direction); got != test.want {
+			t.Errorf("CompassPoint(%v) = %s, want %s", test.direction, got, test.want)
+		}
+	}
+}
+
+func TestBeaufort(t *testing.T) {
+	tests := []struct {
+		speed float64
+		want  int
+	}{
+		{0, 0},
+		{0.49, 0},
+		{0.5, 1},
+		{1.49, 1},
+		{1.5, 2},
+		{3.29, 2},
+		{3.3, 3},
+		{5.49, 3},
+		{5.5, 4},
+		{7.89, 4},
+		{7.9, 5},
+		{10.69, 5},
+		{10.7, 6},
+		{13.79, 6},
+		{13.8, 7},
+		{17.09, 7},
+		{17.1, 8},
+		{20.69, 8},
+		{20.7, 9},
+		{24.39, 9},
+		{24.4, 10},
+		{28.39, 10},
+		{28.4, 11},
+		{32.59, 11},
+		{32.6, 12},
+		{60, 12},
+	}
+	for _, test := range tests {
+		if got := Beaufort(test.speed); got != test.want {
+			t.Errorf("Beaufort(%v) = %d, want %d", test.speed, got, test.want)
+		}
+	}
+}
+
+func TestWindComponents(t *testing.T) {
+	// Directions are where the wind blows from, so a northerly wind blows southward and has a negative v
+	tests := []struct {
+		direction float64
+		wantU     float64
+		wantV     float64
+	}{
+		{0, 0, -10},
+		{45, -7.07, -7.07},
+		{90, -10, 0},
+		{135, -7.07, 7.07},
+		{180, 0, 10},
+		{225, 7.07, 7.07},
+		{270, 10, 0},
+		{315, 7.07, -7.07},
+		{360, 0, -10},
+	}
+	for _, test := range tests {
+		u, v := WindComponents(10, test.direction)
+		if u != test.wantU || v != test.wantV {
+			t.Errorf("WindComponents(10, %v) = %v, %v, want %v, %v", test.direction, u, v, test.wantU, test.wantV)
+		}
+	}
+}
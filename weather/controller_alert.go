@@ -0,0 +1,300 @@
+package weather
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AlertController is Controller that manages alert rules, evaluates them against forecasts and delivers alerts
+type AlertController struct {
+	// Notifiers are the sinks alerts are delivered to, by channel
+	Notifiers map[string]Notifier
+}
+
+// AlertFilter narrows down the alert history. Zero fields don't filter.
+type AlertFilter struct {
+	LocationID     string
+	RuleID         string
+	Since          time.Time
+	Unacknowledged bool
+	Limit          int
+}
+
+// newID returns a random identifier
+func newID() string {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(bytes)
+}
+
+// alertStateKey identifies what a rule last notified for a location
+func alertStateKey(ruleID, locationID string) string {
+	return ruleID + "_" + locationID
+}
+
+// SaveAlertRule validates and stores an alert rule, creating it when it has no ID yet
+func (al *AlertController) SaveAlertRule(db Database, rule AlertRule) (*AlertRule, error) {
+	if err := rule.validate(); err != nil {
+		return nil, fmt.Errorf("invalid alert rule: %v", err)
+	}
+
+	now := time.Now().UTC()
+	if rule.ID == "" {
+		rule.ID = newID()
+		rule.CreatedAt = now
+	} else {
+		existing, err := al.GetAlertRule(db, rule.ID)
+		if err != nil {
+			return nil, err
+		}
+		rule.CreatedAt = existing.CreatedAt
+	}
+	rule.UpdatedAt = now
+
+	if err := db.d.Write("alert_rules", rule.ID, rule); err != nil {
+		return nil, fmt.Errorf("could not save alert rule: %v", err)
+	}
+	return &rule, nil
+}
+
+// GetAlertRule retrieves an alert rule by ID
+func (al *AlertController) GetAlertRule(db Database, id string) (*AlertRule, error) {
+	var rule AlertRule
+	if err := db.d.Read("alert_rules", id, &rule); err != nil {
+		return nil, fmt.Errorf("alert rule with id %s does not exist", id)
+	}
+	return &rule, nil
+}
+
+// GetAlertRules retrieves every alert rule, oldest first
+func (al *AlertController) GetAlertRules(db Database) ([]*AlertRule, error) {
+	rules := []*AlertRule{}
+
+	records, err := db.d.ReadAll("alert_rules")
+	if err != nil {
+		// No rule has been created yet
+		return rules, nil
+	}
+
+	for _, record := range records {
+		var rule AlertRule
+		if err := json.Unmarshal([]byte(record), &rule); err != nil {
+			return nil, fmt.Errorf("could not unmarshal alert rule: %v", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+	return rules, nil
+}
+
+// DeleteAlertRule removes an alert rule along with its deduplication state. Its past alerts are kept.
+func (al *AlertController) DeleteAlertRule(db Database, lc LocationController, id string) (*AlertRule, error) {
+	rule, err := al.GetAlertRule(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.d.Delete("alert_rules", id); err != nil {
+		return nil, fmt.Errorf("could not delete alert rule with ID %s: %v", id, err)
+	}
+
+	locations, err := lc.GetLocations(db)
+	if err != nil {
+		return rule, nil
+	}
+	for _, location := range locations {
+		// The rule may never have fired for the location
+		db.d.Delete("alert_state", alertStateKey(id, location.ID))
+	}
+	return rule, nil
+}
+
+// EvaluateAll checks every enabled rule against the latest forecast of the locations it watches, and delivers
+// an alert for each new breach outside the cooldown of its rule
+func (al *AlertController) EvaluateAll(ctx context.Context, db Database, lc LocationController, wc WeatherController) error {
+	rules, err := al.GetAlertRules(db)
+	if err != nil {
+		return err
+	}
+
+	locations, err := lc.GetLocations(db)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, location := range locations {
+		// Evaluate all the rules of a location against a single forecast
+		var watching []*AlertRule
+		var metrics []string
+		seen := map[string]bool{}
+		for _, rule := range rules {
+			if !rule.Enabled || !rule.watches(location.ID) {
+				continue
+			}
+			watching = append(watching, rule)
+			if !seen[rule.Metric] {
+				seen[rule.Metric] = true
+				metrics = append(metrics, rule.Metric)
+			}
+		}
+		if len(watching) == 0 {
+			continue
+		}
+
+		forecast, err := wc.LatestWeatherForecast(db, location, metrics)
+		if err != nil {
+			fmt.Printf("Error evaluating alerts of location %s: %v\n", location.ID, err)
+			failed++
+			continue
+		}
+
+		for _, rule := range watching {
+			if err := al.evaluate(ctx, db, rule, location, forecast); err != nil {
+				fmt.Printf("Error evaluating alert rule %s for location %s: %v\n", rule.ID, location.ID, err)
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not evaluate %d alert rules", failed)
+	}
+	return nil
+}
+
+// evaluate checks a rule against the forecast of a location and delivers an alert when needed
+func (al *AlertController) evaluate(ctx context.Context, db Database, rule *AlertRule, location Location, forecast *WeatherForecastInfo) error {
+	now := time.Now().UTC()
+	b, ok := rule.firstBreach(forecast, now)
+	if !ok {
+		return nil
+	}
+
+	var state AlertState
+	key := alertStateKey(rule.ID, location.ID)
+	// A missing state means the rule never fired for the location
+	db.d.Read("alert_state", key, &state)
+	if !state.shouldNotify(rule, b, now) {
+		return nil
+	}
+
+	alert := &Alert{
+		ID:           now.Format(forecastHistoryKeyLayout) + "-" + newID(),
+		RuleID:       rule.ID,
+		RuleName:     rule.Name,
+		LocationID:   location.ID,
+		LocationName: location.Name,
+		Metric:       rule.Metric,
+		Operator:     rule.Operator,
+		Threshold:    rule.Threshold,
+		Value:        b.value,
+		Unit:         b.unit,
+		ForecastTime: b.time,
+		TriggeredAt:  now,
+		Deliveries:   []AlertDelivery{},
+	}
+	alert.Message = alert.message()
+
+	for _, channel := range rule.Channels {
+		delivery := AlertDelivery{Channel: channel, Status: "sent", At: time.Now().UTC()}
+		notifier, ok := al.Notifiers[channel]
+		if !ok {
+			delivery.Status, delivery.Error = "failed", "no notifier configured"
+		} else if err := notifier.Notify(ctx, rule, alert); err != nil {
+			delivery.Status, delivery.Error = "failed", err.Error()
+		}
+		alert.Deliveries = append(alert.Deliveries, delivery)
+	}
+
+	if err := db.d.Write("alerts", alert.ID, alert); err != nil {
+		return fmt.Errorf("could not save alert: %v", err)
+	}
+
+	state.remember(b, now)
+	if err := db.d.Write("alert_state", key, state); err != nil {
+		return fmt.Errorf("could not save alert state: %v", err)
+	}
+	return nil
+}
+
+// RunEvaluator evaluates every alert rule once per interval until ctx is done
+func (al *AlertController) RunEvaluator(ctx context.Context, db Database, lc LocationController, wc WeatherController, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := al.EvaluateAll(ctx, db, lc, wc); err != nil {
+			fmt.Printf("Error evaluating alerts: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetAlerts retrieves the alert history matching a filter, newest first
+func (al *AlertController) GetAlerts(db Database, filter AlertFilter) ([]*Alert, error) {
+	alerts := []*Alert{}
+
+	records, err := db.d.ReadAll("alerts")
+	if err != nil {
+		// No alert has been triggered yet
+		return alerts, nil
+	}
+
+	for _, record := range records {
+		var alert Alert
+		if err := json.Unmarshal([]byte(record), &alert); err != nil {
+			return nil, fmt.Errorf("could not unmarshal alert: %v", err)
+		}
+		if filter.LocationID != "" && alert.LocationID != filter.LocationID {
+			continue
+		}
+		if filter.RuleID != "" && alert.RuleID != filter.RuleID {
+			continue
+		}
+		if !filter.Since.IsZero() && alert.TriggeredAt.Before(filter.Since) {
+			continue
+		}
+		if filter.Unacknowledged && alert.Acknowledged {
+			continue
+		}
+		alerts = append(alerts, &alert)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].TriggeredAt.After(alerts[j].TriggeredAt)
+	})
+	if filter.Limit > 0 && len(alerts) > filter.Limit {
+		alerts = alerts[:filter.Limit]
+	}
+	return alerts, nil
+}
+
+// AcknowledgeAlert marks an alert of the feed as seen
+func (al *AlertController) AcknowledgeAlert(db Database, id string) (*Alert, error) {
+	var alert Alert
+	if err := db.d.Read("alerts", id, &alert); err != nil {
+		return nil, fmt.Errorf("alert with id %s does not exist", id)
+	}
+
+	alert.Acknowledged = true
+	if err := db.d.Write("alerts", id, alert); err != nil {
+		return nil, fmt.Errorf("could not save alert: %v", err)
+	}
+	return &alert, nil
+}
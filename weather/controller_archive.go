@@ -0,0 +1,277 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// maxArchiveDays is the longest range that can be requested from the archive at once
+const maxArchiveDays = 366
+
+// DefaultArchiveMetrics are the metrics returned by the historicalWeather query when none are requested
+var DefaultArchiveMetrics = map[string][]string{
+	ResolutionDaily: {
+		"temperature_2m_max",
+		"temperature_2m_min",
+		"wind_speed_10m_max",
+		"weather_code",
+		"wind_direction_10m_dominant",
+	},
+	ResolutionHourly: {
+		"temperature_2m",
+		"cloud_cover",
+	},
+}
+
+// ArchiveController is Controller that handles operations on observed weather
+type ArchiveController struct {
+	Provider ArchiveProvider
+	// SettleDays is how many days recent observations may still be corrected upstream; older ones are cached for good
+	SettleDays int
+}
+
+// archiveCollection is the collection caching the observed weather of a location, one record per day
+func archiveCollection(locationID, resolution string) string {
+	return "archive/" + locationID + "/" + resolution
+}
+
+// FetchHistoricalWeather returns observed weather of a location between two dates, both included.
+// Settled days are served from the local cache and only missing days are requested from the provider.
+func (ac *ArchiveController) FetchHistoricalWeather(db Database, location Location, start, end time.Time, metrics []string, resolution string) (*HistoricalWeather, error) {
+	if resolution == "" {
+		resolution = ResolutionDaily
+	}
+	if len(metrics) == 0 {
+		metrics = DefaultArchiveMetrics[resolution]
+	}
+
+	series, err := ac.FetchObservedSeries(db, location, start, end, metrics, resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	historical := &HistoricalWeather{
+		ID:           location.ID,
+		LocationName: location.Name,
+		Latitude:     location.Latitude,
+		Longitude:    location.Longitude,
+		Start:        truncateToDay(start).Format(dateLayout),
+		End:          truncateToDay(end).Format(dateLayout),
+		Resolution:   resolution,
+	}
+
+	if resolution == ResolutionDaily {
+		err = series.decode(metrics, &historical.Daily, &historical.DailyUnits)
+	} else {
+		err = series.decode(metrics, &historical.Hourly, &historical.HourlyUnits)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return historical, nil
+}
+
+// FetchObservedSeries returns the raw observed values of a location between two dates, both included,
+// keeping missing observations as nil. Settled days are served from the local cache.
+func (ac *ArchiveController) FetchObservedSeries(db Database, location Location, start, end time.Time, metrics []string, resolution string) (*ArchiveSeries, error) {
+	if resolution != ResolutionDaily && resolution != ResolutionHourly {
+		return nil, fmt.Errorf("unsupported resolution %q, expected %q or %q", resolution, ResolutionDaily, ResolutionHourly)
+	}
+
+	start, end = truncateToDay(start), truncateToDay(end)
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date %s is before start date %s", end.Format(dateLayout), start.Format(dateLayout))
+	}
+	if end.Sub(start) >= maxArchiveDays*24*time.Hour {
+		return nil, fmt.Errorf("date range is longer than %d days", maxArchiveDays)
+	}
+
+	collection := archiveCollection(location.ID, resolution)
+	days := map[string]*ArchiveSeries{}
+	var missing []time.Time
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		var cached ArchiveSeries
+		if err := db.d.Read(collection, day.Format(dateLayout), &cached); err == nil && cached.covers(metrics) {
+			days[day.Format(dateLayout)] = &cached
+			continue
+		}
+		missing = append(missing, day)
+	}
+
+	if len(missing) > 0 {
+		fetched, err := ac.Provider.FetchArchive(location, missing[0], missing[len(missing)-1], metrics, resolution)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch archive data: %w", err)
+		}
+
+		settled := truncateToDay(time.Now()).AddDate(0, 0, -ac.SettleDays)
+		fetchedDays := fetched.splitByDay()
+		for _, day := range missing {
+			key := day.Format(dateLayout)
+			daySeries, ok := fetchedDays[key]
+			if !ok {
+				continue
+			}
+			days[key] = daySeries
+
+			// History is immutable once settled, so keep it for good
+			if day.Before(settled) {
+				var cached ArchiveSeries
+				if err := db.d.Read(collection, key, &cached); err == nil {
+					daySeries = cached.merge(daySeries)
+				}
+				if err := db.d.Write(collection, key, daySeries); err != nil {
+					fmt.Println("Error", fmt.Errorf("could not cache archive data: %v", err))
+				}
+			}
+		}
+	}
+
+	series := &ArchiveSeries{Values: map[string][]*float64{}, Units: map[string]string{}}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if daySeries, ok := days[day.Format(dateLayout)]; ok {
+			series.append(daySeries, metrics)
+		}
+	}
+
+	return series, nil
+}
+
+// truncateToDay drops the time of day, keeping the date as it is in the given time's location
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// covers reports whether the series holds a value of every metric for every time step
+func (s *ArchiveSeries) covers(metrics []string) bool {
+	if len(s.Time) == 0 {
+		return false
+	}
+	for _, metric := range metrics {
+		if len(s.Values[metric]) != len(s.Time) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitByDay splits a series into one series per date
+func (s *ArchiveSeries) splitByDay() map[string]*ArchiveSeries {
+	days := map[string]*ArchiveSeries{}
+	for i, step := range s.Time {
+		if len(step) < len(dateLayout) {
+			continue
+		}
+		key := step[:len(dateLayout)]
+		day, ok := days[key]
+		if !ok {
+			day = &ArchiveSeries{Values: map[string][]*float64{}, Units: s.Units}
+			days[key] = day
+		}
+		day.Time = append(day.Time, step)
+		for metric, values := range s.Values {
+			var value *float64
+			if i < len(values) {
+				value = values[i]
+			}
+			day.Values[metric] = append(day.Values[metric], value)
+		}
+	}
+	return days
+}
+
+// merge returns the series completed with the metrics of another series covering the same time steps
+func (s *ArchiveSeries) merge(other *ArchiveSeries) *ArchiveSeries {
+	if len(s.Time) != len(other.Time) {
+		return other
+	}
+	merged := &ArchiveSeries{Time: other.Time, Values: map[string][]*float64{}, Units: map[string]string{}}
+	for _, source := range []*ArchiveSeries{s, other} {
+		for metric, values := range source.Values {
+			merged.Values[metric] = values
+		}
+		for metric, unit := range source.Units {
+			merged.Units[metric] = unit
+		}
+	}
+	return merged
+}
+
+// append adds the given metrics of another series after the time steps of this series
+func (s *ArchiveSeries) append(other *ArchiveSeries, metrics []string) {
+	s.Time = append(s.Time, other.Time...)
+	for _, metric := range metrics {
+		values := other.Values[metric]
+		for i := range other.Time {
+			var value *float64
+			if i < len(values) {
+				value = values[i]
+			}
+			s.Values[metric] = append(s.Values[metric], value)
+		}
+	}
+	for metric, unit := range other.Units {
+		s.Units[metric] = unit
+	}
+}
+
+// decode maps the series onto typed daily or hourly data and their units. Missing values become NaN in float
+// columns and nil in integer columns, and a metric the data has no column for is an error.
+func (s *ArchiveSeries) decode(metrics []string, data interface{}, units *Units) error {
+	columns := reflect.ValueOf(data).Elem()
+	fields := map[string]reflect.Value{}
+	for i := 0; i < columns.NumField(); i++ {
+		name, _, _ := strings.Cut(columns.Type().Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = columns.Field(i)
+		}
+	}
+
+	fields["time"].Set(reflect.ValueOf(s.Time))
+	unitColumns := map[string]string{"time": s.Units["time"]}
+	for _, metric := range metrics {
+		column, ok := fields[metric]
+		if !ok {
+			return fmt.Errorf("unsupported archive metric %s", metric)
+		}
+		switch column.Interface().(type) {
+		case []float64:
+			values := make([]float64, len(s.Values[metric]))
+			for i, value := range s.Values[metric] {
+				values[i] = math.NaN()
+				if value != nil {
+					values[i] = *value
+				}
+			}
+			column.Set(reflect.ValueOf(values))
+		case []*int:
+			values := make([]*int, len(s.Values[metric]))
+			for i, value := range s.Values[metric] {
+				if value != nil {
+					n := int(math.Round(*value))
+					values[i] = &n
+				}
+			}
+			column.Set(reflect.ValueOf(values))
+		default:
+			return fmt.Errorf("unsupported archive metric %s", metric)
+		}
+		unitColumns[metric] = s.Units[metric]
+	}
+
+	encoded, err := json.Marshal(unitColumns)
+	if err != nil {
+		return fmt.Errorf("failed to encode archive units: %w", err)
+	}
+	if err := json.Unmarshal(encoded, units); err != nil {
+		return fmt.Errorf("failed to decode archive units: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package weather
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// archivePayload is an Open-Meteo archive response of two days, the second not observed yet
+const archivePayload = `{
+	"daily_units": {"time": "iso8601", "temperature_2m_max": "°C", "weather_code": "wmo code"},
+	"daily": {
+		"time": ["2024-05-01", "2024-05-02"],
+		"temperature_2m_max": [18.4, null],
+		"weather_code": [3, null]
+	}
+}`
+
+func TestArchiveSeriesDecodeMissingValues(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	metrics := []string{"temperature_2m_max", "weather_code"}
+	series, err := parseArchiveResponse([]byte(archivePayload), day, day.AddDate(0, 0, 1), metrics, ResolutionDaily)
+	if err != nil {
+		t.Fatalf("could not parse archive: %v", err)
+	}
+
+	var daily DailyData
+	var units Units
+	if err := series.decode(metrics, &daily, &units); err != nil {
+		t.Fatalf("could not decode archive: %v", err)
+	}
+
+	if len(daily.Time) != 2 || daily.Time[1] != "2024-05-02" {
+		t.Fatalf("time = %v, want both days", daily.Time)
+	}
+	if daily.Temperature2mMax[0] != 18.4 || !math.IsNaN(daily.Temperature2mMax[1]) {
+		t.Errorf("temperature_2m_max = %v, want [18.4 NaN]", daily.Temperature2mMax)
+	}
+	if daily.WeatherCode[0] == nil || *daily.WeatherCode[0] != 3 || daily.WeatherCode[1] != nil {
+		t.Errorf("weather_code = %v, want [3 nil]", daily.WeatherCode)
+	}
+	if units.Temperature2mMax != "°C" || units.Time != "iso8601" {
+		t.Errorf("units = %+v", units)
+	}
+
+	days := daily.Days()
+	if days[1].TemperatureMax != nil || days[1].WeatherCode != nil {
+		t.Errorf("missing values of %s are not nil: %+v", days[1].Date, days[1])
+	}
+}
+
+func TestArchiveSeriesDecodeUnsupportedMetric(t *testing.T) {
+	value := 6.5
+	series := &ArchiveSeries{
+		Time:   []string{"2024-05-01"},
+		Values: map[string][]*float64{"sunshine_duration": {&value}},
+		Units:  map[string]string{},
+	}
+
+	var daily DailyData
+	err := series.decode([]string{"sunshine_duration"}, &daily, &Units{})
+	if err == nil || !strings.Contains(err.Error(), "sunshine_duration") {
+		t.Errorf("decoding an unsupported metric returned %v, want an error naming it", err)
+	}
+}
+
+func TestParseArchiveResponseInvalidUnits(t *testing.T) {
+	body := `{"daily_units": ["°C"], "daily": {"time": ["2024-05-01"], "temperature_2m_max": [18.4]}}`
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := parseArchiveResponse([]byte(body), day, day, []string{"temperature_2m_max"}, ResolutionDaily); err == nil {
+		t.Error("archive with invalid units was parsed")
+	}
+}
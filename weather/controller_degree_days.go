@@ -0,0 +1,112 @@
+package weather
+
+import (
+	"fmt"
+	"time"
+)
+
+// degreeDayMetrics are the daily metrics degree days are computed from
+var degreeDayMetrics = []string{"temperature_2m_max", "temperature_2m_min"}
+
+// DegreeDayController is Controller that computes heating and cooling degree days
+type DegreeDayController struct {
+}
+
+// DegreeDaysForForecast computes the degree days of a forecast. Its temperatures are fetched when the forecast
+// was requested without them, and the season-to-date aggregate completes it with observed weather.
+func (dc *DegreeDayController) DegreeDaysForForecast(db Database, wc WeatherController, ac ArchiveController, forecast *WeatherForecastInfo, options DegreeDayOptions) (*DegreeDays, error) {
+	var location Location
+	if err := db.d.Read("locations", forecast.ID, &location); err != nil {
+		return nil, fmt.Errorf("location not found: %v", err)
+	}
+
+	days := dailyTemperatures(forecast.Daily, forecast.Hourly)
+	if len(days) == 0 {
+		withTemperatures, err := wc.LatestWeatherForecast(db, location, degreeDayMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch forecast temperatures: %v", err)
+		}
+		days = dailyTemperatures(withTemperatures.Daily, withTemperatures.Hourly)
+	}
+
+	return dc.degreeDays(db, ac, location, days, options)
+}
+
+// DegreeDaysForHistorical computes the degree days of observed weather, from hourly values at hourly resolution
+// and from daily extremes otherwise
+func (dc *DegreeDayController) DegreeDaysForHistorical(db Database, ac ArchiveController, historical *HistoricalWeather, options DegreeDayOptions) (*DegreeDays, error) {
+	var location Location
+	if err := db.d.Read("locations", historical.ID, &location); err != nil {
+		return nil, fmt.Errorf("location not found: %v", err)
+	}
+
+	var days []DayTemperatures
+	if historical.Resolution == ResolutionHourly {
+		days = hourlyTemperatures(historical.Hourly)
+	}
+
+	// Daily values are read from the archive again, as missing observations must be skipped rather than read as zero
+	if len(days) == 0 {
+		start, errStart := time.Parse(dateLayout, historical.Start)
+		end, errEnd := time.Parse(dateLayout, historical.End)
+		if errStart != nil || errEnd != nil {
+			return nil, fmt.Errorf("invalid historical weather range %s - %s", historical.Start, historical.End)
+		}
+		series, err := ac.FetchObservedSeries(db, location, start, end, degreeDayMetrics, ResolutionDaily)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch observed temperatures: %v", err)
+		}
+		days = observedTemperatures(series)
+	}
+
+	return dc.degreeDays(db, ac, location, days, options)
+}
+
+// degreeDays computes degree days and prepares the season-to-date aggregate, which adds the observed days
+// between the start of the heating season and the first day of the data
+func (dc *DegreeDayController) degreeDays(db Database, ac ArchiveController, location Location, days []DayTemperatures, options DegreeDayOptions) (*DegreeDays, error) {
+	result, err := ComputeDegreeDays(days, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return result, nil
+	}
+
+	first, errFirst := time.Parse(dateLayout, days[0].Date)
+	last, errLast := time.Parse(dateLayout, days[len(days)-1].Date)
+	if errFirst != nil || errLast != nil {
+		return result, nil
+	}
+
+	result.seasonToDate = func() (*DegreeDayAggregate, error) {
+		start := seasonStart(last)
+
+		seasonDays := []DayTemperatures{}
+		if start.Before(first) {
+			series, err := ac.FetchObservedSeries(db, location, start, first.AddDate(0, 0, -1), degreeDayMetrics, ResolutionDaily)
+			if err != nil {
+				return nil, fmt.Errorf("could not fetch observed temperatures since %s: %v", start.Format(dateLayout), err)
+			}
+			seasonDays = append(seasonDays, observedTemperatures(series)...)
+		}
+		for _, day := range days {
+			if day.Date >= start.Format(dateLayout) {
+				seasonDays = append(seasonDays, day)
+			}
+		}
+
+		season, err := ComputeDegreeDays(seasonDays, options)
+		if err != nil {
+			return nil, err
+		}
+
+		aggregate := &DegreeDayAggregate{Period: fmt.Sprintf("%d/%d", start.Year(), start.Year()+1)}
+		for _, day := range season.Daily {
+			aggregate.add(day)
+		}
+		return aggregate, nil
+	}
+
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package weather
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// forecastHistoryKeyLayout names history records so that they sort by issue time
+const forecastHistoryKeyLayout = "20060102T150405.000000000Z"
+
+// forecastHistoryCollection is the collection holding the forecast history of a location
+func forecastHistoryCollection(locationID string) string {
+	return "forecast_history/" + locationID
+}
+
+// SaveForecastHistory stores a fetched forecast as a timestamped snapshot of its location.
+// Forecasts identical to the forecast snapshot of the location are skipped, and snapshots older than the retention
+// are removed.
+func (wc *WeatherController) SaveForecastHistory(db Database, info *WeatherForecastInfo) error {
+	if snapshot, err := wc.GetForecastSnapshot(db, info.ID); err == nil {
+		if sameForecastValues(&snapshot.Forecast, info) {
+			return nil
+		}
+	}
+
+	key := info.FetchedAt.UTC().Format(forecastHistoryKeyLayout)
+	if err := db.d.Write(forecastHistoryCollection(info.ID), key, info); err != nil {
+		return fmt.Errorf("could not save forecast history: %v", err)
+	}
+
+	return wc.pruneForecastHistory(db, info.ID)
+}
+
+// sameForecastValues reports whether two forecasts hold the same values. They are compared encoded, as missing values
+// are NaN and NaN never equals itself.
+func sameForecastValues(a, b *WeatherForecastInfo) bool {
+	left, err := json.Marshal([]interface{}{a.Daily, a.Hourly})
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal([]interface{}{b.Daily, b.Hourly})
+	return err == nil && bytes.Equal(left, right)
+}
+
+// GetForecastHistory retrieves the forecasts of a location issued within the given range, oldest first.
+// A zero issuedAfter or issuedBefore leaves that side of the range open.
+func (wc *WeatherController) GetForecastHistory(db Database, locationID string, issuedAfter, issuedBefore time.Time) ([]*WeatherForecastInfo, error) {
+	history, err := wc.readForecastHistory(db, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var forecasts []*WeatherForecastInfo
+	for _, forecast := range history {
+		if !issuedAfter.IsZero() && forecast.FetchedAt.Before(issuedAfter) {
+			continue
+		}
+		if !issuedBefore.IsZero() && forecast.FetchedAt.After(issuedBefore) {
+			continue
+		}
+		forecasts = append(forecasts, forecast)
+	}
+
+	return forecasts, nil
+}
+
+// readForecastHistory reads every stored forecast of a location, oldest first
+func (wc *WeatherController) readForecastHistory(db Database, locationID string) ([]*WeatherForecastInfo, error) {
+	var history []*WeatherForecastInfo
+
+	records, err := db.d.ReadAll(forecastHistoryCollection(locationID))
+	if err != nil {
+		// Nothing has been stored for this location yet
+		return history, nil
+	}
+
+	for _, record := range records {
+		var forecast WeatherForecastInfo
+		if err := json.Unmarshal([]byte(record), &forecast); err != nil {
+			return nil, fmt.Errorf("could not unmarshal forecast history: %v", err)
+		}
+		history = append(history, &forecast)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].FetchedAt.Before(history[j].FetchedAt)
+	})
+
+	return history, nil
+}
+
+// pruneForecastHistory removes the snapshots of a location that are older than the retention. Record names are
+// issue times that sort chronologically, so expired snapshots are found without reading them.
+func (wc *WeatherController) pruneForecastHistory(db Database, locationID string) error {
+	if wc.ForecastHistoryRetention <= 0 {
+		return nil
+	}
+
+	collection := forecastHistoryCollection(locationID)
+	keys, err := db.d.List(collection)
+	if err != nil {
+		return fmt.Errorf("could not list forecast history: %v", err)
+	}
+
+	cutoff := time.Now().Add(-wc.ForecastHistoryRetention).UTC().Format(forecastHistoryKeyLayout)
+	for _, key := range keys {
+		if key >= cutoff {
+			break
+		}
+		if err := db.d.Delete(collection, key); err != nil && !isMissing(err) {
+			return fmt.Errorf("could not delete forecast history: %v", err)
+		}
+	}
+
+	return nil
+}
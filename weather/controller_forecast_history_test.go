@@ -0,0 +1,70 @@
+package weather
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveForecastHistorySkipsForecastOfSnapshot(t *testing.T) {
+	db := BootstrapDatabase(t.TempDir())
+	wc := &WeatherController{}
+	collection := forecastHistoryCollection("52.5200_13.4050")
+
+	forecast := fixtureForecast()
+	if err := wc.SaveForecastHistory(db, forecast); err != nil {
+		t.Fatalf("could not save forecast history: %v", err)
+	}
+	if err := wc.SaveForecastSnapshot(db, forecast, DefaultForecastMetrics); err != nil {
+		t.Fatalf("could not save forecast snapshot: %v", err)
+	}
+
+	unchanged := fixtureForecast()
+	unchanged.FetchedAt = forecast.FetchedAt.Add(10 * time.Minute)
+	if err := wc.SaveForecastHistory(db, unchanged); err != nil {
+		t.Fatalf("could not save forecast history: %v", err)
+	}
+	if keys, _ := db.d.List(collection); len(keys) != 1 {
+		t.Errorf("history holds %d forecasts after an unchanged one, want 1", len(keys))
+	}
+
+	changed := fixtureForecast()
+	changed.FetchedAt = forecast.FetchedAt.Add(20 * time.Minute)
+	changed.Daily.Temperature2mMax = []float64{22.0, 19.5}
+	if err := wc.SaveForecastHistory(db, changed); err != nil {
+		t.Fatalf("could not save forecast history: %v", err)
+	}
+	if keys, _ := db.d.List(collection); len(keys) != 2 {
+		t.Errorf("history holds %d forecasts after a changed one, want 2", len(keys))
+	}
+}
+
+func TestSaveForecastHistoryPrunesByName(t *testing.T) {
+	dir := t.TempDir()
+	db := BootstrapDatabase(dir)
+	wc := &WeatherController{ForecastHistoryRetention: 24 * time.Hour}
+	collection := forecastHistoryCollection("52.5200_13.4050")
+
+	// Expired records are removed without being read, so one that can't be decoded goes too
+	expired := time.Now().Add(-48 * time.Hour).UTC().Format(forecastHistoryKeyLayout)
+	if err := os.MkdirAll(filepath.Join(dir, collection), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, collection, expired+".json"), []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	kept := fixtureForecast()
+	kept.FetchedAt = time.Now().Add(-time.Hour).UTC()
+	if err := wc.SaveForecastHistory(db, kept); err != nil {
+		t.Fatalf("could not save forecast history: %v", err)
+	}
+
+	keys, err := db.d.List(collection)
+	if err != nil {
+		t.Fatalf("could not list forecast history: %v", err)
+	}
+	if want := kept.FetchedAt.Format(forecastHistoryKeyLayout); len(keys) != 1 || keys[0] != want {
+		t.Errorf("history holds %v, want only %s", keys, want)
+	}
+}
@@ -0,0 +1,177 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// maxGreenWindowHorizon is the furthest green windows are looked for, in hours
+const maxGreenWindowHorizon = maxForecastDays * 24
+
+// GreenWindowController is Controller that finds when flexible loads can run on local renewable production
+type GreenWindowController struct {
+}
+
+// GreenWindowRequest holds what to schedule and which local production is available. Solar and Turbine are optional
+// but at least one of them is needed.
+type GreenWindowRequest struct {
+	DurationHours int
+	HorizonHours  int
+	Limit         int
+	Load          LoadProfile
+	Solar         *SolarSystem
+	Turbine       *WindTurbine
+}
+
+// validate checks the request can be scheduled
+func (request GreenWindowRequest) validate() error {
+	switch {
+	case request.Solar == nil && request.Turbine == nil:
+		return fmt.Errorf("a solar system or a wind turbine is needed")
+	case request.DurationHours < 1:
+		return fmt.Errorf("duration must be at least one hour")
+	case request.HorizonHours < request.DurationHours || request.HorizonHours > maxGreenWindowHorizon:
+		return fmt.Errorf("horizon must be between the duration and %d hours", maxGreenWindowHorizon)
+	case request.Load.FlexibleLoadKw <= 0:
+		return fmt.Errorf("flexible load must be positive")
+	case len(request.Load.HourlyBaseLoadKw) != 0 && len(request.Load.HourlyBaseLoadKw) != 24:
+		return fmt.Errorf("hourly base load must have 24 values")
+	}
+	return nil
+}
+
+// baseLoad returns the base consumption in kW during the hour starting at the given local time
+func (load LoadProfile) baseLoad(start time.Time) float64 {
+	if len(load.HourlyBaseLoadKw) == 24 {
+		return load.HourlyBaseLoadKw[start.Hour()]
+	}
+	return load.BaseLoadKw
+}
+
+// GreenWindows forecasts local solar and wind production, removes the base load, and ranks the non-overlapping
+// windows of the requested duration by how much of the flexible load the remaining surplus covers
+func (gc *GreenWindowController) GreenWindows(wc WeatherController, pv SolarController, wp WindController, location Location, request GreenWindowRequest) ([]*GreenWindow, error) {
+	if err := request.validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %v", err)
+	}
+
+	days := int(math.Ceil(float64(request.HorizonHours)/24)) + 1
+	if days > maxForecastDays {
+		days = maxForecastDays
+	}
+
+	// Production of each hour, keyed by the start of the hour
+	production := map[int64]float64{}
+	zone := time.UTC
+	if request.Solar != nil {
+		solar, err := pv.SolarForecast(wc, location, *request.Solar, days)
+		if err != nil {
+			return nil, err
+		}
+		for _, hour := range solar.Hourly {
+			end, err := time.Parse(time.RFC3339, hour.Time)
+			if err != nil {
+				continue
+			}
+			production[end.Add(-time.Hour).Unix()] += hour.EnergyKwh
+			zone = end.Location()
+		}
+	}
+	if request.Turbine != nil {
+		wind, err := wp.WindPowerForecast(wc, location, *request.Turbine, days)
+		if err != nil {
+			return nil, err
+		}
+		for _, hour := range wind.Hourly {
+			start, err := time.Parse(time.RFC3339, hour.Time)
+			if err != nil {
+				continue
+			}
+			production[start.Unix()] += hour.EnergyKwh
+			zone = start.Location()
+		}
+	}
+
+	// Covered flexible load of each hour of the horizon
+	first := time.Now().Truncate(time.Hour).Add(time.Hour)
+	hours := make([]float64, request.HorizonHours)
+	known := make([]bool, request.HorizonHours)
+	for i := range hours {
+		start := first.Add(time.Duration(i) * time.Hour)
+		produced, ok := production[start.Unix()]
+		if !ok {
+			continue
+		}
+		surplus := produced - request.Load.baseLoad(start.In(zone))
+		hours[i] = math.Max(0, math.Min(surplus, request.Load.FlexibleLoadKw))
+		known[i] = true
+	}
+
+	loadKwh := request.Load.FlexibleLoadKw * float64(request.DurationHours)
+	windows := []*GreenWindow{}
+	for _, c := range selectWindows(hours, known, request.DurationHours, request.Limit) {
+		start := first.Add(time.Duration(c.start) * time.Hour).In(zone)
+		windows = append(windows, &GreenWindow{
+			Rank:         len(windows) + 1,
+			Start:        start.Format(time.RFC3339),
+			End:          start.Add(time.Duration(request.DurationHours) * time.Hour).Format(time.RFC3339),
+			RenewableKwh: round(c.covered, 2),
+			LoadKwh:      round(loadKwh, 2),
+			Coverage:     round(c.covered/loadKwh, 3),
+		})
+	}
+
+	return windows, nil
+}
+
+// windowCandidate is a window of hours starting at an index of the horizon, with the flexible load it covers
+type windowCandidate struct {
+	start   int
+	covered float64
+}
+
+// selectWindows ranks the non-overlapping windows of duration hours by the load they cover, best first and earliest
+// first among equals. Windows holding an hour of unknown production are skipped, and at most limit windows are
+// selected, every window when limit is 0.
+func selectWindows(hours []float64, known []bool, duration, limit int) []windowCandidate {
+	var candidates []windowCandidate
+	for start := 0; start+duration <= len(hours); start++ {
+		var covered float64
+		complete := true
+		for i := start; i < start+duration; i++ {
+			if !known[i] {
+				complete = false
+				break
+			}
+			covered += hours[i]
+		}
+		if complete {
+			candidates = append(candidates, windowCandidate{start: start, covered: covered})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].covered > candidates[j].covered
+	})
+
+	taken := make([]bool, len(hours))
+	var selected []windowCandidate
+	for _, c := range candidates {
+		if limit > 0 && len(selected) >= limit {
+			break
+		}
+		overlaps := false
+		for i := c.start; i < c.start+duration; i++ {
+			overlaps = overlaps || taken[i]
+		}
+		if overlaps {
+			continue
+		}
+		for i := c.start; i < c.start+duration; i++ {
+			taken[i] = true
+		}
+		selected = append(selected, c)
+	}
+	return selected
+}
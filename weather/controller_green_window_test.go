@@ -0,0 +1,64 @@
+package weather
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectWindows(t *testing.T) {
+	allKnown := []bool{true, true, true, true, true, true}
+	tests := []struct {
+		name     string
+		hours    []float64
+		known    []bool
+		duration int
+		limit    int
+		want     []windowCandidate
+	}{
+		{
+			// The windows at 0 and 2 cover as much as the one at 4 but overlap the best window at 1
+			name:     "ranked without overlaps",
+			hours:    []float64{1, 3, 3, 1, 0, 2},
+			known:    allKnown,
+			duration: 2,
+			want:     []windowCandidate{{start: 1, covered: 6}, {start: 4, covered: 2}},
+		},
+		{
+			name:     "limited",
+			hours:    []float64{1, 3, 3, 1, 0, 2},
+			known:    allKnown,
+			duration: 2,
+			limit:    1,
+			want:     []windowCandidate{{start: 1, covered: 6}},
+		},
+		{
+			name:     "earliest first among equals",
+			hours:    []float64{2, 2, 2, 2},
+			known:    allKnown[:4],
+			duration: 1,
+			limit:    2,
+			want:     []windowCandidate{{start: 0, covered: 2}, {start: 1, covered: 2}},
+		},
+		{
+			// The windows holding hour 1 would cover the most, but its production is unknown
+			name:     "hours missing data skipped",
+			hours:    []float64{5, 5, 1, 1, 1},
+			known:    []bool{true, false, true, true, true},
+			duration: 2,
+			want:     []windowCandidate{{start: 2, covered: 2}},
+		},
+		{
+			name:     "horizon shorter than the duration",
+			hours:    []float64{1, 1},
+			known:    allKnown[:2],
+			duration: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := selectWindows(test.hours, test.known, test.duration, test.limit); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("selectWindows = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package weather
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DependencyStatus is the health of a dependency of the server, with details for operators
+type DependencyStatus struct {
+	Name    string                 `json:"name"`
+	Healthy bool                   `json:"healthy"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// StatusProvider reports the health of a dependency. Providers report from what they observed rather than by
+// calling upstream, so /status stays cheap and can be faked.
+type StatusProvider interface {
+	Status() DependencyStatus
+}
+
+// StatusReport is the health of the server and its dependencies
+type StatusReport struct {
+	Status       string             `json:"status"`
+	StartedAt    time.Time          `json:"startedAt"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+// CacheStats counts the weather requests served from snapshots and those that had to be fetched
+type CacheStats struct {
+	Name string
+
+	mu     sync.Mutex
+	hits   int
+	misses int
+}
+
+// SnapshotCache counts the requests served from current and forecast snapshots
+var SnapshotCache = &CacheStats{Name: "snapshots"}
+
+// record counts a request as a hit or a miss
+func (c *CacheStats) record(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hit {
+		c.hits++
+		cacheRequests.Inc(c.Name, "hit")
+	} else {
+		c.misses++
+		cacheRequests.Inc(c.Name, "miss")
+	}
+}
+
+// Counts returns the number of hits and misses
+func (c *CacheStats) Counts() (hits int, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Status reports the hit ratio of the cache, which is always healthy
+func (c *CacheStats) Status() DependencyStatus {
+	hits, misses := c.Counts()
+	details := map[string]interface{}{"hits": hits, "misses": misses}
+	if hits+misses > 0 {
+		details["hitRatio"] = round(float64(hits)/float64(hits+misses), 3)
+	}
+	return DependencyStatus{Name: c.Name, Healthy: true, Details: details}
+}
+
+// healthCollection is where storage probes are written
+const healthCollection = "health"
+
+// Check writes and deletes a probe record to make sure the storage is writable. Every check writes a probe of its own
+// so concurrent checks don't delete each other's.
+func (db Database) Check() error {
+	if db.d == nil {
+		return fmt.Errorf("storage is not initialized")
+	}
+	probe := "probe-" + newID()
+	if err := db.d.Write(healthCollection, probe, map[string]time.Time{"at": time.Now().UTC()}); err != nil {
+		return fmt.Errorf("storage is not writable: %v", err)
+	}
+	if err := db.d.Delete(healthCollection, probe); err != nil && !isMissing(err) {
+		return fmt.Errorf("storage is not writable: %v", err)
+	}
+	return nil
+}
+
+// Status reports whether the storage is writable
+func (db Database) Status() DependencyStatus {
+	status := DependencyStatus{Name: "storage", Healthy: true, Details: map[string]interface{}{"backend": "scribble"}}
+	if err := db.Check(); err != nil {
+		status.Healthy = false
+		status.Details["error"] = err.Error()
+	}
+	return status
+}
+
+// Status reports the scheduler unhealthy once it missed a refresh. Lag is how far behind the last successful
+// refresh the scheduler is.
+func (s *Scheduler) Status() DependencyStatus {
+	started, succeeded, err := s.LastRun()
+	details := map[string]interface{}{"interval": s.Interval.String()}
+	healthy := true
+
+	if !started.IsZero() {
+		details["lastRun"] = started.UTC()
+	}
+	if !succeeded.IsZero() {
+		details["lastSuccess"] = succeeded.UTC()
+		lag := time.Since(succeeded) - s.Interval
+		if lag < 0 {
+			lag = 0
+		}
+		details["lagSeconds"] = round(lag.Seconds(), 1)
+		healthy = time.Since(succeeded) <= 2*s.Interval
+	} else if !started.IsZero() {
+		healthy = time.Since(started) <= 2*s.Interval
+	}
+	if err != nil {
+		details["lastError"] = err.Error()
+	}
+	return DependencyStatus{Name: "scheduler", Healthy: healthy, Details: details}
+}
+
+// HealthController is Controller that reports on the health of the server
+type HealthController struct {
+	DB Database
+	// Providers are the dependencies listed by /status
+	Providers []StatusProvider
+	StartedAt time.Time
+
+	seeded atomic.Bool
+}
+
+// MarkSeeded records that the initial locations were stored
+func (hc *HealthController) MarkSeeded() {
+	hc.seeded.Store(true)
+}
+
+// Readiness checks the server can take requests: storage is writable and locations are seeded
+func (hc *HealthController) Readiness() error {
+	if !hc.seeded.Load() {
+		return fmt.Errorf("locations are not seeded")
+	}
+	return hc.DB.Check()
+}
+
+// Status reports on every dependency, degraded when any is unhealthy
+func (hc *HealthController) Status() StatusReport {
+	report := StatusReport{Status: "ok", StartedAt: hc.StartedAt.UTC()}
+	for _, provider := range hc.Providers {
+		status := provider.Status()
+		if !status.Healthy {
+			report.Status = "degraded"
+		}
+		report.Dependencies = append(report.Dependencies, status)
+	}
+	return report
+}
@@ -0,0 +1,94 @@
+package weather
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProvider reports a fixed status
+type fakeProvider DependencyStatus
+
+func (p fakeProvider) Status() DependencyStatus {
+	return DependencyStatus(p)
+}
+
+func TestHealthControllerStatus(t *testing.T) {
+	startedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 7200))
+	tests := []struct {
+		name      string
+		providers []StatusProvider
+		want      string
+	}{
+		{"no dependencies", nil, "ok"},
+		{"healthy", []StatusProvider{
+			fakeProvider{Name: "storage", Healthy: true},
+			fakeProvider{Name: "scheduler", Healthy: true},
+		}, "ok"},
+		{"one unhealthy", []StatusProvider{
+			fakeProvider{Name: "storage", Healthy: true},
+			fakeProvider{Name: "open-meteo-forecast", Healthy: false, Details: map[string]interface{}{"lastError": "503 Service Unavailable"}},
+			fakeProvider{Name: "scheduler", Healthy: true},
+		}, "degraded"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hc := &HealthController{Providers: test.providers, StartedAt: startedAt}
+			report := hc.Status()
+			if report.Status != test.want {
+				t.Errorf("status = %s, want %s", report.Status, test.want)
+			}
+			if !report.StartedAt.Equal(startedAt) || report.StartedAt.Location() != time.UTC {
+				t.Errorf("startedAt = %v, want %v in UTC", report.StartedAt, startedAt)
+			}
+			if len(report.Dependencies) != len(test.providers) {
+				t.Fatalf("got %d dependencies, want %d", len(report.Dependencies), len(test.providers))
+			}
+			for i, provider := range test.providers {
+				if want := provider.Status(); report.Dependencies[i].Name != want.Name || report.Dependencies[i].Healthy != want.Healthy {
+					t.Errorf("dependency %d = %+v, want %+v", i, report.Dependencies[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthControllerReadiness(t *testing.T) {
+	db := BootstrapDatabase(t.TempDir())
+
+	hc := &HealthController{DB: db}
+	if err := hc.Readiness(); err == nil {
+		t.Error("ready before locations are seeded")
+	}
+	hc.MarkSeeded()
+	if err := hc.Readiness(); err != nil {
+		t.Errorf("not ready once seeded: %v", err)
+	}
+
+	uninitialized := &HealthController{}
+	uninitialized.MarkSeeded()
+	if err := uninitialized.Readiness(); err == nil {
+		t.Error("ready without storage")
+	}
+}
+
+func TestDatabaseCheckConcurrently(t *testing.T) {
+	db := BootstrapDatabase(t.TempDir())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- db.Check()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("check failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package weather
+
+import (
+	"fmt"
+	"time"
+)
+
+// HeatDemandController is Controller that forecasts the heating need and heat pump consumption of buildings
+type HeatDemandController struct {
+}
+
+// HeatDemandForecast computes the hourly heating load, heat pump COP and electricity use of a building from the
+// hourly temperature forecast of its location, with daily totals
+func (hc *HeatDemandController) HeatDemandForecast(db Database, wc WeatherController, location Location, building Building) (*HeatDemandForecast, error) {
+	if err := building.validate(); err != nil {
+		return nil, fmt.Errorf("invalid building: %v", err)
+	}
+
+	weatherForecast, err := wc.LatestWeatherForecast(db, location, []string{"temperature_2m"})
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch temperature forecast: %v", err)
+	}
+
+	forecast := &HeatDemandForecast{
+		ID:           location.ID,
+		LocationName: location.Name,
+		Latitude:     location.Latitude,
+		Longitude:    location.Longitude,
+		Building:     building,
+		Hourly:       []*HeatDemandHour{},
+		Daily:        []*HeatDemandDay{},
+		FetchedAt:    weatherForecast.FetchedAt,
+	}
+	if forecast.FetchedAt.IsZero() {
+		forecast.FetchedAt = time.Now().UTC()
+	}
+
+	hourly := weatherForecast.Hourly
+	var day *HeatDemandDay
+	for i, step := range hourly.Time {
+		if i >= len(hourly.Temperature2m) || len(step) < len(dateLayout) {
+			break
+		}
+
+		outdoor := hourly.Temperature2m[i]
+		load := building.heatLoad(outdoor)
+		cop := building.cop(outdoor)
+		electricity := load / cop
+
+		forecast.Hourly = append(forecast.Hourly, &HeatDemandHour{
+			Time:               step,
+			OutdoorTemperature: outdoor,
+			HeatKwh:            round(load, 3),
+			COP:                round(cop, 2),
+			ElectricityKwh:     round(electricity, 3),
+		})
+
+		date := step[:len(dateLayout)]
+		if day == nil || day.Date != date {
+			day = &HeatDemandDay{Date: date}
+			forecast.Daily = append(forecast.Daily, day)
+		}
+		day.HeatKwh += load
+		day.ElectricityKwh += electricity
+		if load > day.PeakLoadKw {
+			day.PeakLoadKw = round(load, 3)
+		}
+		forecast.TotalHeatKwh += load
+		forecast.TotalElectricityKwh += electricity
+	}
+
+	for _, day := range forecast.Daily {
+		if day.ElectricityKwh > 0 {
+			day.COP = round(day.HeatKwh/day.ElectricityKwh, 2)
+		}
+		day.HeatKwh = round(day.HeatKwh, 2)
+		day.ElectricityKwh = round(day.ElectricityKwh, 2)
+	}
+	forecast.TotalHeatKwh = round(forecast.TotalHeatKwh, 2)
+	forecast.TotalElectricityKwh = round(forecast.TotalElectricityKwh, 2)
+
+	return forecast, nil
+}
@@ -0,0 +1,97 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxForecastDays is the furthest Open-Meteo forecasts reach
+const maxForecastDays = 16
+
+// HourlySeries represents hourly forecast values of arbitrary Open-Meteo metrics, for models needing more
+// than the metrics of HourlyData
+type HourlySeries struct {
+	// Location is the time zone of the forecasted place, used to present times locally
+	Location *time.Location
+	// Time holds the time steps; instantaneous values apply at them and radiation values average the preceding hour
+	Time   []time.Time
+	Values map[string][]*float64
+	Units  map[string]string
+}
+
+// Value returns the value of a metric at a time step, and whether it is known
+func (s *HourlySeries) Value(metric string, i int) (float64, bool) {
+	values := s.Values[metric]
+	if i >= len(values) || values[i] == nil {
+		return 0, false
+	}
+	return *values[i], true
+}
+
+// FetchHourlySeries fetches hourly forecast values of the given metrics for a location over the next days
+func (wc *WeatherController) FetchHourlySeries(location Location, metrics []string, days int) (*HourlySeries, error) {
+	if days < 1 || days > maxForecastDays {
+		return nil, fmt.Errorf("forecast days must be between 1 and %d", maxForecastDays)
+	}
+
+	query := wc.forecastQuery(fmt.Sprintf("latitude=%s&longitude=%s&hourly=%s&forecast_days=%d&timezone=auto&timeformat=unixtime&format=json",
+		location.Latitude,
+		location.Longitude,
+		strings.Join(metrics, ","),
+		days,
+	))
+
+	resp, err := ForecastUpstream.Get(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch weather data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var payload struct {
+		Timezone         string                     `json:"timezone"`
+		UtcOffsetSeconds int                        `json:"utc_offset_seconds"`
+		HourlyUnits      map[string]string          `json:"hourly_units"`
+		Hourly           map[string]json.RawMessage `json:"hourly"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse weather data: %w", err)
+	}
+
+	zone, err := time.LoadLocation(payload.Timezone)
+	if err != nil {
+		zone = time.FixedZone(payload.Timezone, payload.UtcOffsetSeconds)
+	}
+
+	var timestamps []int64
+	if err := json.Unmarshal(payload.Hourly["time"], &timestamps); err != nil {
+		return nil, fmt.Errorf("failed to parse weather time steps: %w", err)
+	}
+
+	series := &HourlySeries{Location: zone, Values: map[string][]*float64{}, Units: payload.HourlyUnits}
+	for _, timestamp := range timestamps {
+		series.Time = append(series.Time, time.Unix(timestamp, 0).In(zone))
+	}
+	for _, metric := range metrics {
+		var values []*float64
+		if raw, ok := payload.Hourly[metric]; ok {
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return nil, fmt.Errorf("failed to parse weather metric %s: %w", metric, err)
+			}
+		}
+		series.Values[metric] = values
+	}
+
+	return series, nil
+}
@@ -8,6 +8,15 @@ import (
 
 // LocationController is Controller that handles operations on locations
 type LocationController struct {
+    // OnChange is called with the event type and the location after a location was created, updated or deleted
+    OnChange func(event string, location Location)
+}
+
+// notify reports a location change to OnChange
+func (lc *LocationController) notify(event string, location Location) {
+    if lc.OnChange != nil {
+        lc.OnChange(event, location)
+    }
 }
 
 // GenerateID generates a unique identifier for the location based on latitude and longitude
@@ -33,9 +42,26 @@ func (lc *LocationController) AddLocation(db Database, newLocation Location) err
         return fmt.Errorf("could not save location: %v", err)
     }
 
+    lc.notify(EventLocationCreated, newLocation)
     return nil
 }
 
+// UpdateLocation renames a location. Its coordinates can't change since they make up its ID.
+func (lc *LocationController) UpdateLocation(db Database, id string, name string) (*Location, error) {
+    var location Location
+    if err := db.d.Read("locations", id, &location); err != nil {
+        return nil, fmt.Errorf("location with id %s does not exist", id)
+    }
+
+    location.Name = name
+    if err := db.d.Write("locations", id, location); err != nil {
+        return nil, fmt.Errorf("could not save location: %v", err)
+    }
+
+    lc.notify(EventLocationUpdated, location)
+    return &location, nil
+}
+
 // GetLocations retrieves all locations from the database
 func (lc *LocationController) GetLocations(db Database) ([]Location, error) {
     var locations []Location
@@ -58,7 +84,8 @@ func (lc *LocationController) GetLocations(db Database) ([]Location, error) {
     return locations, nil
 }
 
-// DeleteLocation removes a location from the database based on its unique ID
+// DeleteLocation removes a location from the database based on its unique ID, along with its weather snapshots and
+// forecast history
 func (lc *LocationController) DeleteLocation(db Database, id string) error {
 
     // Check if the location exists in the database
@@ -68,17 +95,30 @@ func (lc *LocationController) DeleteLocation(db Database, id string) error {
         return fmt.Errorf("location with id %s does not exist", id)
     }
 
+    // Delete the weather stored for the location first, so that it is not served or counted once the location is gone
+    for _, stored := range []struct{ collection, resource string }{
+        {"current_snapshots", id},
+        {"forecast_snapshots", id},
+        {forecastHistoryCollection(id), ""},
+    } {
+        if err := db.d.Delete(stored.collection, stored.resource); err != nil && !isMissing(err) {
+            return fmt.Errorf("could not delete the weather stored for location with ID %s: %v", id, err)
+        }
+    }
+
     // Delete the location from the database
     if err := db.d.Delete("locations", id); err != nil {
         return fmt.Errorf("could not delete location with ID %s: %v", id, err)
     }
 
     fmt.Printf("Location %s with ID %s deleted successfully.\n", location.Name, id)
+    lc.notify(EventLocationDeleted, location)
     return nil
 }
 
-// InitializeLocations populates the database with initial location data for each German state
-func (lc *LocationController) InitializeLocations(db Database) {
+// InitializeLocations populates the database with initial location data for each German state, returning an error
+// when some could not be saved
+func (lc *LocationController) InitializeLocations(db Database) error {
     // List of coordinates for each German state
     locations := []Location{
         {Name: "Baden-Württemberg", Latitude: "48.6616", Longitude: "9.3501"},
@@ -100,6 +140,7 @@ func (lc *LocationController) InitializeLocations(db Database) {
     }
 
     // Iterate through each location and add it to the database if not present
+    var failed int
     for _, location := range locations {
         // Generate the unique ID from the coordinates
         location.ID = GenerateID(location.Latitude, location.Longitude)
@@ -115,9 +156,15 @@ func (lc *LocationController) InitializeLocations(db Database) {
         // Save the location to the database
         if err := db.d.Write("locations", location.ID, location); err != nil {
             fmt.Printf("Error saving location %s: %v\n", location.Name, err)
+            failed++
         } else {
             fmt.Printf("Location %s saved successfully.\n", location.Name)
         }
     }
+
+    if failed > 0 {
+        return fmt.Errorf("could not save %d out of %d initial locations", failed, len(locations))
+    }
+    return nil
 }
 
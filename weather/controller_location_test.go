@@ -0,0 +1,50 @@
+package weather
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeleteLocationRemovesStoredWeather(t *testing.T) {
+	db := BootstrapDatabase(t.TempDir())
+	lc := &LocationController{}
+	wc := &WeatherController{SnapshotMaxAge: time.Hour}
+
+	forecast := fixtureForecast()
+	forecast.FetchedAt = time.Now()
+	location := Location{ID: forecast.ID, Name: forecast.LocationName, Latitude: forecast.Latitude, Longitude: forecast.Longitude}
+	if err := db.d.Write("locations", location.ID, location); err != nil {
+		t.Fatal(err)
+	}
+	if err := wc.SaveCurrentSnapshot(db, &CurrentWeatherInfo{ID: location.ID, FetchedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := wc.SaveForecastSnapshot(db, forecast, DefaultForecastMetrics); err != nil {
+		t.Fatal(err)
+	}
+	if err := wc.SaveForecastHistory(db, forecast); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lc.DeleteLocation(db, location.ID); err != nil {
+		t.Fatalf("DeleteLocation failed: %v", err)
+	}
+
+	if snapshots, _ := wc.GetCurrentSnapshots(db); len(snapshots) != 0 {
+		t.Errorf("%d current weather snapshots left", len(snapshots))
+	}
+	if _, err := wc.GetForecastSnapshot(db, location.ID); err == nil {
+		t.Error("forecast snapshot left")
+	}
+	if history, _ := wc.GetForecastHistory(db, location.ID, time.Time{}, time.Time{}); len(history) != 0 {
+		t.Errorf("%d forecasts left in the history", len(history))
+	}
+
+	// A location that never had weather fetched is deleted too
+	if err := db.d.Write("locations", location.ID, location); err != nil {
+		t.Fatal(err)
+	}
+	if err := lc.DeleteLocation(db, location.ID); err != nil {
+		t.Errorf("DeleteLocation of a location without weather failed: %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultForecastMetrics are the daily metrics kept in forecast snapshots
+var DefaultForecastMetrics = []string{
+	"temperature_2m_max",
+	"temperature_2m_min",
+	"wind_speed_10m_max",
+	"weather_code",
+	"wind_direction_10m_dominant",
+	"uv_index_max",
+}
+
+// ForecastSnapshot is the latest forecast stored for a location along with the metrics it covers
+type ForecastSnapshot struct {
+	Metrics  []string            `json:"metrics"`
+	Forecast WeatherForecastInfo `json:"forecast"`
+}
+
+// SaveCurrentSnapshot stores the latest current weather of a location
+func (wc *WeatherController) SaveCurrentSnapshot(db Database, info *CurrentWeatherInfo) error {
+	if err := db.d.Write("current_snapshots", info.ID, info); err != nil {
+		return fmt.Errorf("could not save current weather snapshot: %v", err)
+	}
+	return nil
+}
+
+// SaveForecastSnapshot stores the latest forecast of a location
+func (wc *WeatherController) SaveForecastSnapshot(db Database, info *WeatherForecastInfo, metrics []string) error {
+	snapshot := ForecastSnapshot{Metrics: metrics, Forecast: *info}
+	if err := db.d.Write("forecast_snapshots", info.ID, snapshot); err != nil {
+		return fmt.Errorf("could not save forecast snapshot: %v", err)
+	}
+	return nil
+}
+
+// GetCurrentSnapshots retrieves the stored current weather of every location, keyed by location ID
+func (wc *WeatherController) GetCurrentSnapshots(db Database) (map[string]*CurrentWeatherInfo, error) {
+	snapshots := map[string]*CurrentWeatherInfo{}
+
+	records, err := db.d.ReadAll("current_snapshots")
+	if err != nil {
+		// Nothing has been stored yet
+		return snapshots, nil
+	}
+
+	for _, record := range records {
+		var info CurrentWeatherInfo
+		if err := json.Unmarshal([]byte(record), &info); err != nil {
+			return nil, fmt.Errorf("could not unmarshal current weather snapshot: %v", err)
+		}
+		snapshots[info.ID] = &info
+	}
+
+	return snapshots, nil
+}
+
+// GetForecastSnapshot retrieves the stored forecast of a location
+func (wc *WeatherController) GetForecastSnapshot(db Database, locationID string) (*ForecastSnapshot, error) {
+	var snapshot ForecastSnapshot
+	if err := db.d.Read("forecast_snapshots", locationID, &snapshot); err != nil {
+		return nil, fmt.Errorf("no forecast snapshot for location %s", locationID)
+	}
+	return &snapshot, nil
+}
+
+// isFresh reports whether data fetched at the given time may still be served
+func (wc *WeatherController) isFresh(fetchedAt time.Time) bool {
+	return wc.SnapshotMaxAge > 0 && time.Since(fetchedAt) <= wc.SnapshotMaxAge
+}
+
+// covers reports whether a snapshot holds every requested metric
+func (snapshot *ForecastSnapshot) covers(metrics []string) bool {
+	return coversMetrics(snapshot.Metrics, metrics)
+}
+
+// coversMetrics reports whether a set of metrics holds every wanted metric
+func coversMetrics(metrics, wanted []string) bool {
+	available := map[string]bool{}
+	for _, metric := range metrics {
+		available[metric] = true
+	}
+	for _, metric := range wanted {
+		if !available[metric] {
+			return false
+		}
+	}
+	return true
+}
+
+// LatestWeatherForLocations serves the current weather of every location from snapshots when they are all fresh,
+// and fetches and stores it otherwise
+func (wc *WeatherController) LatestWeatherForLocations(db Database, lc LocationController) ([]*CurrentWeatherInfo, error) {
+	locations, err := lc.GetLocations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := wc.GetCurrentSnapshots(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var weatherInfos []*CurrentWeatherInfo
+	for _, location := range locations {
+		snapshot, ok := snapshots[location.ID]
+		if !ok || !wc.isFresh(snapshot.FetchedAt) {
+			weatherInfos = nil
+			break
+		}
+		weatherInfos = append(weatherInfos, snapshot)
+	}
+	SnapshotCache.record(weatherInfos != nil)
+	if weatherInfos != nil {
+		return weatherInfos, nil
+	}
+
+	weatherInfos, err = wc.FetchWeatherForLocations(db, lc)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range weatherInfos {
+		if err := wc.SaveCurrentSnapshot(db, info); err != nil {
+			fmt.Println("Error", err)
+		}
+	}
+	return weatherInfos, nil
+}
+
+// LatestCurrentWeather serves the current weather of the given locations from their fresh snapshots, and fetches
+// and stores the others in a single request. Results are in the order of the locations.
+func (wc *WeatherController) LatestCurrentWeather(db Database, locations []Location) ([]*CurrentWeatherInfo, error) {
+	snapshots, err := wc.GetCurrentSnapshots(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []Location
+	for _, location := range locations {
+		snapshot, ok := snapshots[location.ID]
+		fresh := ok && wc.isFresh(snapshot.FetchedAt)
+		SnapshotCache.record(fresh)
+		if !fresh {
+			stale = append(stale, location)
+		}
+	}
+
+	fetched, err := wc.FetchCurrentWeather(db, stale)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range fetched {
+		if err := wc.SaveCurrentSnapshot(db, info); err != nil {
+			fmt.Println("Error", err)
+		}
+		snapshots[info.ID] = info
+	}
+
+	weatherInfos := make([]*CurrentWeatherInfo, len(locations))
+	for i, location := range locations {
+		weatherInfos[i] = snapshots[location.ID]
+	}
+	return weatherInfos, nil
+}
+
+// LatestWeatherForecast serves the forecast of a location from its snapshot when it is fresh and covers the
+// requested metrics, and fetches it otherwise
+func (wc *WeatherController) LatestWeatherForecast(db Database, location Location, metrics []string) (*WeatherForecastInfo, error) {
+	forecasts, err := wc.LatestWeatherForecasts(db, []Location{location}, metrics)
+	if err != nil {
+		return nil, err
+	}
+	return forecasts[0], nil
+}
+
+// LatestWeatherForecasts serves the forecasts of the given locations from their snapshots when they are fresh and
+// cover the requested metrics, and fetches the others in a single request. Results are in the order of the locations.
+func (wc *WeatherController) LatestWeatherForecasts(db Database, locations []Location, metrics []string) ([]*WeatherForecastInfo, error) {
+	forecasts := make([]*WeatherForecastInfo, len(locations))
+	var stale []Location
+	var staleIndexes []int
+	for i, location := range locations {
+		snapshot, err := wc.GetForecastSnapshot(db, location.ID)
+		fresh := err == nil && wc.isFresh(snapshot.Forecast.FetchedAt) && snapshot.covers(metrics)
+		SnapshotCache.record(fresh)
+		if fresh {
+			forecasts[i] = &snapshot.Forecast
+			continue
+		}
+		stale = append(stale, location)
+		staleIndexes = append(staleIndexes, i)
+	}
+
+	fetched, err := wc.FetchWeatherForecasts(db, stale, metrics)
+	if err != nil {
+		return nil, err
+	}
+	for i, forecast := range fetched {
+		forecasts[staleIndexes[i]] = forecast
+	}
+	return forecasts, nil
+}
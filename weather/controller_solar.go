@@ -0,0 +1,95 @@
+package weather
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SolarController is Controller that estimates photovoltaic production from forecast irradiance
+type SolarController struct {
+}
+
+// SolarForecast estimates the hourly and daily energy produced by a photovoltaic system at a location over the next days
+func (pv *SolarController) SolarForecast(wc WeatherController, location Location, system SolarSystem, days int) (*SolarForecast, error) {
+	if err := system.validate(); err != nil {
+		return nil, fmt.Errorf("invalid solar system: %v", err)
+	}
+
+	latitude, longitude, err := coordinates(location)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := wc.FetchHourlySeries(location, solarMetrics, days)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch irradiance forecast: %v", err)
+	}
+
+	forecast := &SolarForecast{
+		ID:           location.ID,
+		LocationName: location.Name,
+		Latitude:     location.Latitude,
+		Longitude:    location.Longitude,
+		Timezone:     series.Location.String(),
+		System:       system,
+		Hourly:       []*SolarHour{},
+		Daily:        []*SolarDay{},
+		FetchedAt:    time.Now().UTC(),
+	}
+
+	var day *SolarDay
+	for i, end := range series.Time {
+		global, okGlobal := series.Value("shortwave_radiation", i)
+		direct, okDirect := series.Value("direct_radiation", i)
+		diffuse, okDiffuse := series.Value("diffuse_radiation", i)
+		ambient, okAmbient := series.Value("temperature_2m", i)
+		if !okGlobal || !okDirect || !okDiffuse || !okAmbient {
+			continue
+		}
+
+		// Radiation is averaged over the preceding hour, so take the sun position in its middle
+		zenith, azimuth := solarPosition(end.Add(-30*time.Minute), latitude, longitude)
+		irradiance := system.planeOfArrayIrradiance(zenith, azimuth, global, direct, diffuse)
+		power, cellTemperature := system.power(irradiance, ambient)
+
+		forecast.Hourly = append(forecast.Hourly, &SolarHour{
+			Time:            end.Format(time.RFC3339),
+			Irradiance:      round(irradiance, 1),
+			CellTemperature: round(cellTemperature, 1),
+			EnergyKwh:       round(power, 3),
+		})
+
+		// The hour belongs to the day it started in
+		date := end.Add(-time.Hour).Format(dateLayout)
+		if day == nil || day.Date != date {
+			day = &SolarDay{Date: date}
+			forecast.Daily = append(forecast.Daily, day)
+		}
+		day.EnergyKwh += power
+		if power > day.PeakKw {
+			day.PeakKw = round(power, 3)
+		}
+		forecast.TotalKwh += power
+	}
+
+	for _, day := range forecast.Daily {
+		day.EnergyKwh = round(day.EnergyKwh, 2)
+	}
+	forecast.TotalKwh = round(forecast.TotalKwh, 2)
+
+	return forecast, nil
+}
+
+// coordinates parses the latitude and longitude of a location
+func coordinates(location Location) (float64, float64, error) {
+	latitude, err := strconv.ParseFloat(location.Latitude, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid latitude %q: %v", location.Latitude, err)
+	}
+	longitude, err := strconv.ParseFloat(location.Longitude, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid longitude %q: %v", location.Longitude, err)
+	}
+	return latitude, longitude, nil
+}
@@ -0,0 +1,120 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// SubscriptionController is Controller that fans out current weather updates to GraphQL subscribers
+type SubscriptionController struct {
+	// BufferSize is the number of pending updates kept per subscriber before the oldest ones are dropped
+	BufferSize int
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	latest      map[string]*CurrentWeatherInfo
+}
+
+// subscriber is a single open subscription, watching one location, a group of locations or all of them
+type subscriber struct {
+	locationIDs map[string]bool
+	updates     chan interface{}
+	dropped     int
+}
+
+// NewSubscriptionController creates a SubscriptionController keeping up to bufferSize pending updates per subscriber
+func NewSubscriptionController(bufferSize int) *SubscriptionController {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &SubscriptionController{
+		BufferSize:  bufferSize,
+		subscribers: map[*subscriber]struct{}{},
+		latest:      map[string]*CurrentWeatherInfo{},
+	}
+}
+
+// wants reports whether the subscriber watches the given location
+func (s *subscriber) wants(locationID string) bool {
+	return len(s.locationIDs) == 0 || s.locationIDs[locationID]
+}
+
+// push queues an update without ever blocking the publisher, dropping the oldest pending update of slow clients
+func (s *subscriber) push(info *CurrentWeatherInfo) {
+	for {
+		select {
+		case s.updates <- info:
+			return
+		default:
+		}
+		select {
+		case <-s.updates:
+			s.dropped++
+		default:
+		}
+	}
+}
+
+// sameConditions reports whether two readings of a location's weather are identical, regardless of when they were fetched
+func sameConditions(a, b *CurrentWeatherInfo) bool {
+	left, right := *a, *b
+	left.FetchedAt, right.FetchedAt = time.Time{}, time.Time{}
+	return reflect.DeepEqual(left, right)
+}
+
+// Subscribe registers a subscriber for the given location IDs (all locations when empty).
+// The returned channel receives *CurrentWeatherInfo values and is closed once ctx is done.
+func (sc *SubscriptionController) Subscribe(ctx context.Context, locationIDs []string) chan interface{} {
+	s := &subscriber{
+		locationIDs: map[string]bool{},
+		updates:     make(chan interface{}, sc.BufferSize),
+	}
+	for _, id := range locationIDs {
+		s.locationIDs[id] = true
+	}
+
+	sc.mu.Lock()
+	sc.subscribers[s] = struct{}{}
+	// Send the last known conditions right away so clients don't wait for the next change
+	for id, info := range sc.latest {
+		if s.wants(id) {
+			s.push(info)
+		}
+	}
+	sc.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		sc.mu.Lock()
+		delete(sc.subscribers, s)
+		close(s.updates)
+		sc.mu.Unlock()
+		if s.dropped > 0 {
+			fmt.Printf("Subscriber dropped %d weather updates because it was too slow.\n", s.dropped)
+		}
+	}()
+
+	return s.updates
+}
+
+// Publish records the latest weather of a location and sends it to every interested subscriber if it changed.
+// It reports whether the update was a change.
+func (sc *SubscriptionController) Publish(info *CurrentWeatherInfo) bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if previous, ok := sc.latest[info.ID]; ok && sameConditions(previous, info) {
+		return false
+	}
+	sc.latest[info.ID] = info
+
+	for s := range sc.subscribers {
+		if s.wants(info.ID) {
+			s.push(info)
+		}
+	}
+	return true
+}
@@ -0,0 +1,234 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// VerifiedMetrics are the daily forecast metrics compared against observations
+var VerifiedMetrics = []string{
+	"temperature_2m_max",
+	"temperature_2m_min",
+	"wind_speed_10m_max",
+	"uv_index_max",
+}
+
+// VerificationController is Controller that compares stored forecasts with what was later observed
+type VerificationController struct {
+}
+
+// accuracyKey identifies the errors of a metric at a lead time
+type accuracyKey struct {
+	metric   string
+	leadDays int
+}
+
+// accuracySums accumulates forecast errors
+type accuracySums struct {
+	samples  int
+	absolute float64
+	signed   float64
+}
+
+// VerifyLocation compares the forecast history of a location with the observed values and stores the
+// mean absolute error and bias per metric and lead time
+func (vc *VerificationController) VerifyLocation(db Database, wc WeatherController, ac ArchiveController, location Location) ([]*ForecastAccuracy, error) {
+	history, err := wc.GetForecastHistory(db, location.ID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the last forecast issued on each day, history being sorted oldest first
+	issues := map[string]*WeatherForecastInfo{}
+	for _, forecast := range history {
+		issues[localDate(forecast.FetchedAt, forecast)] = forecast
+	}
+
+	// Only days that are over where the location is can be verified
+	now := time.Now()
+	var first, last time.Time
+	for _, forecast := range issues {
+		today, err := time.Parse(dateLayout, localDate(now, forecast))
+		if err != nil {
+			continue
+		}
+		for _, date := range forecast.Daily.Time {
+			target, err := time.Parse(dateLayout, date)
+			if err != nil || !target.Before(today) {
+				continue
+			}
+			if first.IsZero() || target.Before(first) {
+				first = target
+			}
+			if target.After(last) {
+				last = target
+			}
+		}
+	}
+
+	var accuracies []*ForecastAccuracy
+	if first.IsZero() {
+		return accuracies, vc.saveAccuracy(db, location.ID, accuracies)
+	}
+	if last.Sub(first) >= maxArchiveDays*24*time.Hour {
+		first = last.AddDate(0, 0, -(maxArchiveDays - 1))
+	}
+
+	series, err := ac.FetchObservedSeries(db, location, first, last, VerifiedMetrics, ResolutionDaily)
+	if err != nil {
+		return nil, err
+	}
+	observed := map[string]int{}
+	for i, date := range series.Time {
+		observed[date] = i
+	}
+
+	sums := map[accuracyKey]*accuracySums{}
+	for issueDate, forecast := range issues {
+		issued, err := time.Parse(dateLayout, issueDate)
+		if err != nil {
+			continue
+		}
+
+		for _, metric := range VerifiedMetrics {
+			forecasted, ok := forecast.Daily.Series(metric)
+			if !ok {
+				continue
+			}
+
+			for i, date := range forecast.Daily.Time {
+				index, ok := observed[date]
+				if !ok || i >= len(forecasted) || math.IsNaN(forecasted[i]) || index >= len(series.Values[metric]) || series.Values[metric][index] == nil {
+					continue
+				}
+				target, err := time.Parse(dateLayout, date)
+				if err != nil || target.Before(issued) {
+					continue
+				}
+
+				key := accuracyKey{metric: metric, leadDays: int(target.Sub(issued).Hours() / 24)}
+				if sums[key] == nil {
+					sums[key] = &accuracySums{}
+				}
+				difference := forecasted[i] - *series.Values[metric][index]
+				sums[key].samples++
+				sums[key].absolute += math.Abs(difference)
+				sums[key].signed += difference
+			}
+		}
+	}
+
+	verifiedAt := time.Now().UTC()
+	for key, sum := range sums {
+		accuracies = append(accuracies, &ForecastAccuracy{
+			LocationID: location.ID,
+			Metric:     key.metric,
+			LeadDays:   key.leadDays,
+			Samples:    sum.samples,
+			MAE:        sum.absolute / float64(sum.samples),
+			Bias:       sum.signed / float64(sum.samples),
+			VerifiedAt: verifiedAt,
+		})
+	}
+	sortAccuracies(accuracies)
+
+	return accuracies, vc.saveAccuracy(db, location.ID, accuracies)
+}
+
+// localDate returns the date of an instant at the UTC offset of a forecast, whose daily times are local dates
+func localDate(t time.Time, forecast *WeatherForecastInfo) string {
+	return t.In(time.FixedZone("", forecast.UtcOffsetSeconds)).Format(dateLayout)
+}
+
+// VerifyAll verifies the forecasts of every location
+func (vc *VerificationController) VerifyAll(db Database, lc LocationController, wc WeatherController, ac ArchiveController) error {
+	locations, err := lc.GetLocations(db)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, location := range locations {
+		if _, err := vc.VerifyLocation(db, wc, ac, location); err != nil {
+			fmt.Printf("Error verifying forecasts of location %s: %v\n", location.ID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not verify the forecasts of %d out of %d locations", failed, len(locations))
+	}
+	return nil
+}
+
+// RunVerifier verifies the forecasts of every location once per interval until ctx is done
+func (vc *VerificationController) RunVerifier(ctx context.Context, db Database, lc LocationController, wc WeatherController, ac ArchiveController, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := vc.VerifyAll(db, lc, wc, ac); err != nil {
+			fmt.Printf("Error verifying forecasts: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetForecastAccuracy retrieves the stored forecast accuracy of a location, or of every location when locationID is empty
+func (vc *VerificationController) GetForecastAccuracy(db Database, locationID string) ([]*ForecastAccuracy, error) {
+	var accuracies []*ForecastAccuracy
+
+	if locationID != "" {
+		if err := db.d.Read("forecast_accuracy", locationID, &accuracies); err != nil {
+			// The location has not been verified yet
+			return []*ForecastAccuracy{}, nil
+		}
+		return accuracies, nil
+	}
+
+	records, err := db.d.ReadAll("forecast_accuracy")
+	if err != nil {
+		return []*ForecastAccuracy{}, nil
+	}
+	for _, record := range records {
+		var locationAccuracies []*ForecastAccuracy
+		if err := json.Unmarshal([]byte(record), &locationAccuracies); err != nil {
+			return nil, fmt.Errorf("could not unmarshal forecast accuracy: %v", err)
+		}
+		accuracies = append(accuracies, locationAccuracies...)
+	}
+	sortAccuracies(accuracies)
+
+	return accuracies, nil
+}
+
+// saveAccuracy stores the forecast accuracy of a location
+func (vc *VerificationController) saveAccuracy(db Database, locationID string, accuracies []*ForecastAccuracy) error {
+	if err := db.d.Write("forecast_accuracy", locationID, accuracies); err != nil {
+		return fmt.Errorf("could not save forecast accuracy: %v", err)
+	}
+	return nil
+}
+
+// sortAccuracies orders accuracies by location, metric and lead time
+func sortAccuracies(accuracies []*ForecastAccuracy) {
+	sort.Slice(accuracies, func(i, j int) bool {
+		a, b := accuracies[i], accuracies[j]
+		if a.LocationID != b.LocationID {
+			return a.LocationID < b.LocationID
+		}
+		if a.Metric != b.Metric {
+			return a.Metric < b.Metric
+		}
+		return a.LeadDays < b.LeadDays
+	})
+}
@@ -0,0 +1,88 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fixtureArchive is an ArchiveProvider serving fixed daily observations by date
+type fixtureArchive map[string]map[string]*float64
+
+func (a fixtureArchive) FetchArchive(location Location, start, end time.Time, metrics []string, resolution string) (*ArchiveSeries, error) {
+	series := &ArchiveSeries{Values: map[string][]*float64{}, Units: map[string]string{"time": "iso8601"}}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		series.Time = append(series.Time, date)
+		for _, metric := range metrics {
+			series.Values[metric] = append(series.Values[metric], a[date][metric])
+		}
+	}
+	return series, nil
+}
+
+func TestVerifyLocation(t *testing.T) {
+	db := BootstrapDatabase(t.TempDir())
+	wc := WeatherController{}
+	location := Location{ID: "52.5200_13.4050", Name: "Berlin", Latitude: "52.5200", Longitude: "13.4050"}
+
+	first := truncateToDay(time.Now().UTC()).AddDate(0, 0, -5)
+	day := func(n int) string { return first.AddDate(0, 0, n).Format(dateLayout) }
+
+	// The first forecast misses a maximum temperature, which must not count as a forecast of 0
+	history := []string{
+		fmt.Sprintf(`{"id": %q, "fetched_at": %q, "utc_offset_seconds": 0, "daily": {
+			"time": [%q, %q, %q],
+			"temperature_2m_max": [20, 21, null],
+			"temperature_2m_min": [10, 11, 12]
+		}}`, location.ID, first.Add(6*time.Hour).Format(time.RFC3339), day(0), day(1), day(2)),
+		// Fetched at 20:00 UTC 10 hours ahead of UTC, so issued the next local day
+		fmt.Sprintf(`{"id": %q, "fetched_at": %q, "utc_offset_seconds": 36000, "daily": {
+			"time": [%q, %q],
+			"temperature_2m_max": [22, 22]
+		}}`, location.ID, first.Add(20*time.Hour).Format(time.RFC3339), day(1), day(2)),
+	}
+	for _, record := range history {
+		var forecast WeatherForecastInfo
+		if err := json.Unmarshal([]byte(record), &forecast); err != nil {
+			t.Fatalf("could not decode forecast fixture: %v", err)
+		}
+		if err := wc.SaveForecastHistory(db, &forecast); err != nil {
+			t.Fatalf("could not save forecast history: %v", err)
+		}
+	}
+
+	ac := ArchiveController{Provider: fixtureArchive{
+		day(0): {"temperature_2m_max": pointer(19.0), "temperature_2m_min": pointer(10.0)},
+		day(1): {"temperature_2m_max": pointer(23.0)},
+		day(2): {"temperature_2m_max": pointer(22.0), "temperature_2m_min": pointer(13.0)},
+	}}
+
+	vc := &VerificationController{}
+	accuracies, err := vc.VerifyLocation(db, wc, ac, location)
+	if err != nil {
+		t.Fatalf("VerifyLocation failed: %v", err)
+	}
+
+	want := []ForecastAccuracy{
+		{Metric: "temperature_2m_max", LeadDays: 0, Samples: 2, MAE: 1, Bias: 0},
+		{Metric: "temperature_2m_max", LeadDays: 1, Samples: 2, MAE: 1, Bias: -1},
+		{Metric: "temperature_2m_min", LeadDays: 0, Samples: 1, MAE: 0, Bias: 0},
+		{Metric: "temperature_2m_min", LeadDays: 2, Samples: 1, MAE: 1, Bias: -1},
+	}
+	if len(accuracies) != len(want) {
+		t.Fatalf("VerifyLocation returned %d accuracies, want %d: %+v", len(accuracies), len(want), accuracies)
+	}
+	for i, accuracy := range accuracies {
+		got := ForecastAccuracy{Metric: accuracy.Metric, LeadDays: accuracy.LeadDays, Samples: accuracy.Samples, MAE: accuracy.MAE, Bias: accuracy.Bias}
+		if got != want[i] {
+			t.Errorf("accuracy %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	stored, err := vc.GetForecastAccuracy(db, location.ID)
+	if err != nil || len(stored) != len(want) {
+		t.Errorf("GetForecastAccuracy returned %d accuracies and %v, want %d", len(stored), err, len(want))
+	}
+}
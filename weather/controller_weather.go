@@ -1,86 +1,231 @@
 package weather
 
 import (
+    "errors"
     "fmt"
     "io"
+    "math"
     "net/http"
+    "net/url"
     "strings"
     "encoding/json"
+    "time"
 )
+
+// ErrRateLimited is returned when Open-Meteo rejects a request because too many were sent
+var ErrRateLimited = errors.New("open-meteo rate limit exceeded")
+
+// ForecastDays is how many days the forecasts of FetchWeatherForecasts cover
+const ForecastDays = 7
+
 // WeatherController is Controller that handles operations on weather forecasts
 type WeatherController struct{
+    // SnapshotMaxAge is how old a stored snapshot may be and still be served instead of fetching live data
+    SnapshotMaxAge time.Duration
+    // ForecastHistoryRetention is how long fetched forecasts are kept in the forecast history, forever when zero
+    ForecastHistoryRetention time.Duration
+    // OnForecastFetched is called with every forecast fetched from upstream with all of DefaultForecastMetrics
+    OnForecastFetched func(info *WeatherForecastInfo)
+    // ForecastURL is the Open-Meteo forecast endpoint
+    ForecastURL string
+    // APIKey is sent to Open-Meteo when set, as commercial endpoints require
+    APIKey string
+}
+
+// forecastQuery builds a forecast request URL from its query parameters
+func (wc *WeatherController) forecastQuery(params string) string {
+    return wc.ForecastURL + "?" + params + apiKeyParam(wc.APIKey)
+}
+
+// apiKeyParam returns the query parameter sending an Open-Meteo API key, empty without a key
+func apiKeyParam(key string) string {
+    if key == "" {
+        return ""
+    }
+    return "&apikey=" + url.QueryEscape(key)
+}
+
+// checkResponse turns non-successful Open-Meteo responses into errors
+func checkResponse(resp *http.Response) error {
+    if resp.StatusCode == http.StatusTooManyRequests {
+        return ErrRateLimited
+    }
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("open-meteo responded with status %s", resp.Status)
+    }
+    return nil
+}
+
+// hourlyMetrics are the metrics of HourlyData, which Open-Meteo only serves in hourly forecasts
+var hourlyMetrics = map[string]bool{
+    "temperature_2m": true,
+    "cloud_cover":    true,
+    "wind_speed_80m": true,
+    "uv_index":       true,
+}
+
+// splitMetrics separates hourly metrics from daily ones
+func splitMetrics(metrics []string) (hourly []string, daily []string) {
+    for _, metric := range metrics {
+        if hourlyMetrics[metric] {
+            hourly = append(hourly, metric)
+        } else {
+            daily = append(daily, metric)
+        }
+    }
+    return hourly, daily
 }
 
 // [ DAILY/WEEKLY ]  FetchWeatherForecast fetches the weather forecast data for a single location
 func (wc *WeatherController) FetchWeatherForecast(db Database, location Location, metrics []string) (*WeatherForecastInfo, error) { 
-    weatherInfo := &WeatherForecastInfo{}
+    forecasts, err := wc.FetchWeatherForecasts(db, []Location{location}, metrics)
+    if err != nil {
+        return nil, err
+    }
+    return forecasts[0], nil
+}
+
+// FetchWeatherForecasts fetches the forecasts of the given locations in a single request. Results are in the order
+// of the locations, empty for a location Open-Meteo returned no data for.
+func (wc *WeatherController) FetchWeatherForecasts(db Database, locations []Location, metrics []string) ([]*WeatherForecastInfo, error) {
+    var weatherInfos []*WeatherForecastInfo
+    if len(locations) == 0 {
+        return weatherInfos, nil
+    }
 
-    // Construct the daily query parameter from the metrics slice
-    dailyMetrics := strings.Join(metrics, ",")
+    var latitudes, longitudes []string
+    for _, location := range locations {
+        latitudes = append(latitudes, location.Latitude)
+        longitudes = append(longitudes, location.Longitude)
+    }
 
-    // Construct the OpenMeteo API URL with the latitude and longitude
-    query := fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%s&longitude=%s&daily=%s&forecast_days=7&timezone=auto&format=json", 
-        location.Latitude, 
-        location.Longitude, 
-        dailyMetrics,
-    )
+    // Construct the daily and hourly query parameters from the metrics slice
+    hourlyMetrics, dailyMetrics := splitMetrics(metrics)
+
+    // Construct the OpenMeteo API URL with the latitudes and longitudes
+    query := wc.forecastQuery(fmt.Sprintf("latitude=%s&longitude=%s&forecast_days=%d&timezone=auto&format=json", 
+        strings.Join(latitudes, ","), 
+        strings.Join(longitudes, ","), 
+        ForecastDays,
+    ))
+    if len(dailyMetrics) > 0 || len(hourlyMetrics) == 0 {
+        query += "&daily=" + strings.Join(dailyMetrics, ",")
+    }
+    if len(hourlyMetrics) > 0 {
+        query += "&hourly=" + strings.Join(hourlyMetrics, ",")
+    }
 
 
     // Make the HTTP request
-    resp, err := http.Get(query)
+    resp, err := ForecastUpstream.Get(query)
     if err != nil {
         return nil, fmt.Errorf("failed to fetch weather data: %w", err)
     }
     defer resp.Body.Close()
 
+    if err := checkResponse(resp); err != nil {
+        return nil, err
+    }
+
     body, err := io.ReadAll(resp.Body)
     if err != nil {
         return nil, fmt.Errorf("failed to read response body: %w", err)
     }
     
     // Parse the response
-    var weatherData WeatherResponse
-    if err := json.Unmarshal(body, &weatherData); err != nil {
-        return nil, fmt.Errorf("failed to parse weather data: %w", err)
+    weatherData, err := parseWeatherResponses(body, len(locations))
+    if err != nil {
+        return nil, err
     }
-    
-    // Generate LocationID based on the latitude and longitude of the current location
-    var locationID = GenerateID(location.Latitude, location.Longitude)
 
-    // Check if the location exists in the database
-    var existing Location
-    err = db.d.Read("locations", locationID, &existing)
-    if err != nil {
-        // Return a different error message if the location is not found
-        return nil, fmt.Errorf("location with latitude %s and longitude %s does not exist", location.Latitude, location.Longitude)
-    }
-
-    // Map query response from OpenMeteo response
-    if len(weatherData.Daily.Time) > 0 {
-        weatherInfo = &WeatherForecastInfo{
-            LocationName:  existing.Name, // Use the name from the existing location in the database
-            Latitude:      existing.Latitude,
-            Longitude:     existing.Longitude,
-            Daily: weatherData.Daily,
-            DailyUnits: weatherData.DailyUnits,
-            Hourly: weatherData.Hourly,
+    fetchedAt := time.Now().UTC()
+    for index, data := range weatherData {
+        location := locations[index]
+
+        // Generate LocationID based on the latitude and longitude of the current location
+        var locationID = GenerateID(location.Latitude, location.Longitude)
+
+        // Check if the location exists in the database
+        var existing Location
+        err = db.d.Read("locations", locationID, &existing)
+        if err != nil {
+            // Return a different error message if the location is not found
+            return nil, fmt.Errorf("location with latitude %s and longitude %s does not exist", location.Latitude, location.Longitude)
         }
+
+        // Map query response from OpenMeteo response
+        weatherInfo := &WeatherForecastInfo{}
+        if len(data.Daily.Time) > 0 || len(data.Hourly.Time) > 0 {
+            weatherInfo = &WeatherForecastInfo{
+                ID:            existing.ID,
+                LocationName:  existing.Name, // Use the name from the existing location in the database
+                Latitude:      existing.Latitude,
+                Longitude:     existing.Longitude,
+                Daily: data.Daily,
+                DailyUnits: data.DailyUnits,
+                Hourly: data.Hourly,
+                HourlyUnits: data.HourlyUnits,
+                FetchedAt: fetchedAt,
+                UtcOffsetSeconds: data.UtcOffsetSeconds,
+            }
+        }
+
+        // Keep every full forecast so later queries can tell what it said at the time. Forecasts of a few metrics, as
+        // heat demand and alert rules fetch, would otherwise replace the full forecast of the day in the history
+        if weatherInfo.ID != "" && coversMetrics(metrics, DefaultForecastMetrics) {
+            if err := wc.SaveForecastHistory(db, weatherInfo); err != nil {
+                fmt.Println("Error", err)
+            }
+            if wc.OnForecastFetched != nil {
+                wc.OnForecastFetched(weatherInfo)
+            }
+        }
+
+        weatherInfos = append(weatherInfos, weatherInfo)
     }
 
-    return weatherInfo, nil
+    return weatherInfos, nil
+}
+
+// parseWeatherResponses parses the Open-Meteo results of the given number of locations, a single object rather
+// than a list when one location was requested
+func parseWeatherResponses(body []byte, locations int) ([]WeatherResponse, error) {
+    var weatherData []WeatherResponse
+    var err error
+    if locations == 1 {
+        weatherData = make([]WeatherResponse, 1)
+        err = json.Unmarshal(body, &weatherData[0])
+    } else {
+        err = json.Unmarshal(body, &weatherData)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse weather data: %w", err)
+    }
+    if len(weatherData) != locations {
+        return nil, fmt.Errorf("open-meteo returned %d results for %d locations", len(weatherData), locations)
+    }
+    return weatherData, nil
 }
 
 
 // [ MAP ] FetchWeatherForLocations fetches the weather data for multiple locations at once
 func (wc *WeatherController) FetchWeatherForLocations(db Database,lc LocationController) ([]*CurrentWeatherInfo, error) {
+    locations, err := lc.GetLocations(db)
+    if err != nil {
+        return nil, err
+    }
+    return wc.FetchCurrentWeather(db, locations)
+}
+
+// FetchCurrentWeather fetches the current weather of the given locations in a single request
+func (wc *WeatherController) FetchCurrentWeather(db Database, locations []Location) ([]*CurrentWeatherInfo, error) {
 	// Create arrays of latitudes and longitudes
 	var latitudes []string
 	var longitudes []string
     var weatherInfos []*CurrentWeatherInfo
 
-    locations, err := lc.GetLocations(db)
-    if err != nil {
-        return nil, err
+    if len(locations) == 0 {
+        return weatherInfos, nil
     }
 
 	for _, location := range locations {
@@ -93,26 +238,32 @@ func (wc *WeatherController) FetchWeatherForLocations(db Database,lc LocationCon
 	lonStr := strings.Join(longitudes, ",")
 
 	// Construct the OpenMeteo API URL with the latitudes and longitudes
-	query := fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%s&longitude=%s&current=temperature_2m,cloud_cover,wind_speed_80m,wind_direction_10m,weather_code&timezone=auto&format=json", latStr, lonStr)
+	query := wc.forecastQuery(fmt.Sprintf("latitude=%s&longitude=%s&current=temperature_2m,cloud_cover,wind_speed_80m,uv_index,wind_direction_10m,weather_code&daily=temperature_2m_max,temperature_2m_min,uv_index_max&forecast_days=1&timezone=auto&format=json", latStr, lonStr))
 
 	// Make the HTTP request
-	resp, err := http.Get(query)
+	resp, err := ForecastUpstream.Get(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch weather data: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
     body, err := io.ReadAll(resp.Body)
     if err != nil {
         return nil, fmt.Errorf("failed to read response body: %w", err)
     }
     
 	// Parse the response
-	var weatherData []WeatherResponse
-	if err := json.Unmarshal(body, &weatherData); err != nil {
-        return nil, fmt.Errorf("failed to parse weather data: %w", err)
+	weatherData, err := parseWeatherResponses(body, len(locations))
+	if err != nil {
+        return nil, err
 	}
 
+    fetchedAt := time.Now().UTC()
+
         // Map the parsed data to CurrentWeatherInfo
         for index, data := range weatherData {
 
@@ -130,20 +281,43 @@ func (wc *WeatherController) FetchWeatherForLocations(db Database,lc LocationCon
 
             // Map query response from OpenMeteo response
 
+            // The daily extremes and UV index come from today's forecast. Current weather is stored and compared
+            // as plain floats, so missing values stay 0 there.
+            var maxTemperature, minTemperature float64
+            if len(data.Daily.Temperature2mMax) > 0 && len(data.Daily.Temperature2mMin) > 0 {
+                maxTemperature, minTemperature = zeroIfMissing(data.Daily.Temperature2mMax[0]), zeroIfMissing(data.Daily.Temperature2mMin[0])
+            }
+            var uvIndexMax []float64
+            for _, value := range data.Daily.UvIndexMax {
+                uvIndexMax = append(uvIndexMax, zeroIfMissing(value))
+            }
+
             weatherInfos = append(weatherInfos, &CurrentWeatherInfo{
                 ID:                 location.ID, // append Location ID
                 LocationName:       location.Name, // Append Location Name
                 Latitude:           location.Latitude,
                 Longitude:          location.Longitude,
                 Temperature:        data.Current.Temperature2m,
+                MaxTemperature:     maxTemperature,
+                MinTemperature:     minTemperature,
+                UvIndexMax:         uvIndexMax,
                 CloudCoverage:      data.Current.CloudCover,
                 WindSpeed:          data.Current.WindSpeed80m,
                 UvIndex:            data.Current.UvIndex,
                 WeatherCode:        data.Current.WeatherCode,
                 WindDirectionAngle: data.Current.WindDirectionAngle,
                 Units:              data.CurrentUnits,
+                FetchedAt:          fetchedAt,
             })
         }
     
     return weatherInfos, nil
-}
\ No newline at end of file
+}
+
+// zeroIfMissing returns a forecast value, or 0 when it is missing
+func zeroIfMissing(value float64) float64 {
+    if math.IsNaN(value) {
+        return 0
+    }
+    return value
+}
@@ -0,0 +1,498 @@
+package weather
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Webhook event types
+const (
+	EventLocationCreated   = "location.created"
+	EventLocationUpdated   = "location.updated"
+	EventLocationDeleted   = "location.deleted"
+	EventForecastRefreshed = "forecast.refreshed"
+	EventWebhookTest       = "webhook.test"
+)
+
+// WebhookEvents are the event types webhooks can subscribe to
+var WebhookEvents = []string{EventLocationCreated, EventLocationUpdated, EventLocationDeleted, EventForecastRefreshed}
+
+// webhookJob is an event waiting to be delivered to a webhook
+type webhookJob struct {
+	webhook Webhook
+	event   WebhookEvent
+	// attempt is the number of the next delivery attempt, from 1
+	attempt int
+	// due is when a retry may be attempted
+	due time.Time
+}
+
+// WebhookController is Controller that manages webhooks and delivers events to them in the background,
+// signing payloads and retrying failed deliveries with exponential backoff
+type WebhookController struct {
+	// Client posts the events
+	Client *http.Client
+	// MaxAttempts is how many times a delivery is tried before giving up
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled for each following one
+	InitialBackoff time.Duration
+	// Workers is the number of deliveries made concurrently
+	Workers int
+	// DrainTimeout is how long queued events may still be delivered once Run is stopped
+	DrainTimeout time.Duration
+
+	db    Database
+	queue chan webhookJob
+
+	mu sync.Mutex
+	// retries are the failed deliveries waiting for their backoff, queued again once due
+	retries []webhookJob
+	// retried wakes the retry scheduler when a retry is added
+	retried chan struct{}
+	// webhooks caches the stored webhooks for Emit, nil until read and after every change
+	webhooks []*Webhook
+}
+
+// NewWebhookController creates a WebhookController queueing up to bufferSize deliveries
+func NewWebhookController(db Database, bufferSize int) *WebhookController {
+	return &WebhookController{
+		Client:         &http.Client{Timeout: 10 * time.Second},
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Second,
+		Workers:        4,
+		DrainTimeout:   10 * time.Second,
+		db:             db,
+		queue:          make(chan webhookJob, bufferSize),
+		retried:        make(chan struct{}, 1),
+	}
+}
+
+// CreateWebhook validates and stores a webhook, generating its secret when none is given
+func (wh *WebhookController) CreateWebhook(webhook Webhook) (*Webhook, error) {
+	if target, err := url.Parse(webhook.URL); err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		return nil, fmt.Errorf("webhook URL must be an http or https URL")
+	}
+	if len(webhook.Events) == 0 {
+		webhook.Events = WebhookEvents
+	}
+	for _, event := range webhook.Events {
+		if !containsString(WebhookEvents, event) {
+			return nil, fmt.Errorf("unknown event %q", event)
+		}
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = newID() + newID()
+	}
+
+	webhook.ID = newID()
+	webhook.Enabled = true
+	webhook.CreatedAt = time.Now().UTC()
+	if err := wh.db.d.Write("webhooks", webhook.ID, webhook); err != nil {
+		return nil, fmt.Errorf("could not save webhook: %v", err)
+	}
+	wh.invalidateWebhooks()
+	return &webhook, nil
+}
+
+// GetWebhooks retrieves every webhook, oldest first, without their secrets
+func (wh *WebhookController) GetWebhooks() ([]*Webhook, error) {
+	webhooks, err := wh.readWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+	return webhooks, nil
+}
+
+// readWebhooks reads every stored webhook, oldest first
+func (wh *WebhookController) readWebhooks() ([]*Webhook, error) {
+	webhooks := []*Webhook{}
+
+	records, err := wh.db.d.ReadAll("webhooks")
+	if err != nil {
+		// No webhook has been created yet
+		return webhooks, nil
+	}
+
+	for _, record := range records {
+		var webhook Webhook
+		if err := json.Unmarshal([]byte(record), &webhook); err != nil {
+			return nil, fmt.Errorf("could not unmarshal webhook: %v", err)
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	return webhooks, nil
+}
+
+// cachedWebhooks returns the stored webhooks, read from storage only after they changed. The webhooks are shared
+// and must not be modified.
+func (wh *WebhookController) cachedWebhooks() ([]*Webhook, error) {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	if wh.webhooks == nil {
+		webhooks, err := wh.readWebhooks()
+		if err != nil {
+			return nil, err
+		}
+		wh.webhooks = webhooks
+	}
+	return wh.webhooks, nil
+}
+
+// invalidateWebhooks makes the next Emit read the webhooks from storage again
+func (wh *WebhookController) invalidateWebhooks() {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	wh.webhooks = nil
+}
+
+// SetWebhookEnabled pauses or resumes the deliveries to a webhook
+func (wh *WebhookController) SetWebhookEnabled(id string, enabled bool) (*Webhook, error) {
+	var webhook Webhook
+	if err := wh.db.d.Read("webhooks", id, &webhook); err != nil {
+		return nil, fmt.Errorf("webhook with id %s does not exist", id)
+	}
+
+	webhook.Enabled = enabled
+	if err := wh.db.d.Write("webhooks", id, webhook); err != nil {
+		return nil, fmt.Errorf("could not save webhook: %v", err)
+	}
+	wh.invalidateWebhooks()
+	webhook.Secret = ""
+	return &webhook, nil
+}
+
+// DeleteWebhook removes a webhook. Its delivery log is kept.
+func (wh *WebhookController) DeleteWebhook(id string) (*Webhook, error) {
+	var webhook Webhook
+	if err := wh.db.d.Read("webhooks", id, &webhook); err != nil {
+		return nil, fmt.Errorf("webhook with id %s does not exist", id)
+	}
+
+	if err := wh.db.d.Delete("webhooks", id); err != nil {
+		return nil, fmt.Errorf("could not delete webhook with ID %s: %v", id, err)
+	}
+	wh.invalidateWebhooks()
+	webhook.Secret = ""
+	return &webhook, nil
+}
+
+// Emit queues an event for every enabled webhook subscribed to its type. Events are dropped when the queue is full.
+func (wh *WebhookController) Emit(eventType string, data interface{}) {
+	webhooks, err := wh.cachedWebhooks()
+	if err != nil {
+		fmt.Printf("Error emitting %s event: %v\n", eventType, err)
+		return
+	}
+
+	event := WebhookEvent{ID: newID(), Type: eventType, OccurredAt: time.Now().UTC(), Data: data}
+	for _, webhook := range webhooks {
+		if !webhook.Enabled || !containsString(webhook.Events, eventType) {
+			continue
+		}
+		select {
+		case wh.queue <- webhookJob{webhook: *webhook, event: event, attempt: 1}:
+		default:
+			fmt.Printf("Webhook queue full, dropping %s event for webhook %s.\n", eventType, webhook.ID)
+		}
+	}
+}
+
+// Run delivers queued events until ctx is done, then tries each event still queued or waiting for a retry once more
+// within DrainTimeout
+func (wh *WebhookController) Run(ctx context.Context) {
+	scheduled := make(chan struct{})
+	go func() {
+		defer close(scheduled)
+		wh.scheduleRetries(ctx)
+	}()
+
+	wh.work(func() {
+		// Events are left to the drain once ctx is done, even when both are ready
+		for ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case job := <-wh.queue:
+				wh.attempt(ctx, job)
+			}
+		}
+	})
+	<-scheduled
+	wh.drain()
+}
+
+// drain delivers the queued events and those waiting for a retry once each, until none is left or DrainTimeout
+// passed, and logs how many were dropped
+func (wh *WebhookController) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), wh.DrainTimeout)
+	defer cancel()
+
+	wh.mu.Lock()
+	retries := wh.retries
+	wh.retries = nil
+	wh.mu.Unlock()
+
+	var mu sync.Mutex
+	next := func() (webhookJob, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(retries) > 0 {
+			job := retries[0]
+			retries = retries[1:]
+			return job, true
+		}
+		select {
+		case job := <-wh.queue:
+			return job, true
+		default:
+			return webhookJob{}, false
+		}
+	}
+	wh.work(func() {
+		for ctx.Err() == nil {
+			job, ok := next()
+			if !ok {
+				return
+			}
+			wh.deliver(ctx, job, job.attempt)
+		}
+	})
+	if dropped := len(wh.queue) + len(retries); dropped > 0 {
+		fmt.Printf("Webhook deliveries stopped, dropping %d queued events.\n", dropped)
+	}
+}
+
+// work runs a worker loop on each of the Workers and waits for them to return
+func (wh *WebhookController) work(loop func()) {
+	workers := wh.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loop()
+		}()
+	}
+	wg.Wait()
+}
+
+// attempt posts an event once. A failed delivery is retried after a backoff, InitialBackoff doubled for each attempt
+// made, until MaxAttempts were made; the worker moves on to the next event meanwhile.
+func (wh *WebhookController) attempt(ctx context.Context, job webhookJob) {
+	if delivery := wh.deliver(ctx, job, job.attempt); delivery.Status == "delivered" || job.attempt >= wh.MaxAttempts {
+		return
+	}
+
+	job.due = time.Now().Add(wh.InitialBackoff << (job.attempt - 1))
+	job.attempt++
+	wh.mu.Lock()
+	wh.retries = append(wh.retries, job)
+	wh.mu.Unlock()
+	select {
+	case wh.retried <- struct{}{}:
+	default:
+	}
+}
+
+// scheduleRetries queues the failed deliveries again once they are due, until ctx is done
+func (wh *WebhookController) scheduleRetries(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-wh.retried:
+		}
+
+		due, next := wh.dueRetries(time.Now())
+		for i, job := range due {
+			select {
+			case wh.queue <- job:
+			case <-ctx.Done():
+				// Left for the drain
+				wh.mu.Lock()
+				wh.retries = append(wh.retries, due[i:]...)
+				wh.mu.Unlock()
+				return
+			}
+		}
+		if next.IsZero() {
+			timer.Stop()
+		} else {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+// dueRetries removes and returns the retries due at the given time, with the time the next one is due, zero when
+// none is left
+func (wh *WebhookController) dueRetries(now time.Time) ([]webhookJob, time.Time) {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+
+	var due, waiting []webhookJob
+	var next time.Time
+	for _, job := range wh.retries {
+		if !job.due.After(now) {
+			due = append(due, job)
+			continue
+		}
+		waiting = append(waiting, job)
+		if next.IsZero() || job.due.Before(next) {
+			next = job.due
+		}
+	}
+	wh.retries = waiting
+	return due, next
+}
+
+// TestWebhook posts a test event to a webhook right away, once, and returns the outcome
+func (wh *WebhookController) TestWebhook(ctx context.Context, id string) (*WebhookDelivery, error) {
+	var webhook Webhook
+	if err := wh.db.d.Read("webhooks", id, &webhook); err != nil {
+		return nil, fmt.Errorf("webhook with id %s does not exist", id)
+	}
+
+	event := WebhookEvent{
+		ID:         newID(),
+		Type:       EventWebhookTest,
+		OccurredAt: time.Now().UTC(),
+		Data:       map[string]string{"webhook_id": id},
+	}
+	return wh.deliver(ctx, webhookJob{webhook: webhook, event: event}, 1), nil
+}
+
+// deliver posts an event to a webhook once and logs the attempt
+func (wh *WebhookController) deliver(ctx context.Context, job webhookJob, attempt int) *WebhookDelivery {
+	started := time.Now().UTC()
+	delivery := &WebhookDelivery{
+		ID:        started.Format(forecastHistoryKeyLayout) + "-" + newID(),
+		WebhookID: job.webhook.ID,
+		EventID:   job.event.ID,
+		EventType: job.event.Type,
+		Attempt:   attempt,
+		Status:    "failed",
+		At:        started,
+	}
+
+	statusCode, err := wh.post(ctx, job.webhook, job.event, started)
+	delivery.StatusCode = statusCode
+	delivery.DurationMs = float64(time.Since(started).Microseconds()) / 1000
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.Status = "delivered"
+	}
+
+	if err := wh.db.d.Write("webhook_deliveries", delivery.ID, delivery); err != nil {
+		fmt.Printf("Error saving webhook delivery: %v\n", err)
+	}
+	return delivery
+}
+
+// post sends a signed event and returns the response status code
+func (wh *WebhookController) post(ctx context.Context, webhook Webhook, event WebhookEvent, at time.Time) (int, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("could not encode event: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("could not build webhook request: %v", err)
+	}
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GreenHeat-Event", event.Type)
+	req.Header.Set("X-GreenHeat-Delivery", event.ID)
+	req.Header.Set("X-GreenHeat-Timestamp", timestamp)
+	req.Header.Set("X-GreenHeat-Signature", "sha256="+SignWebhookPayload(webhook.Secret, timestamp, payload))
+
+	client := wh.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("could not call webhook: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload computes the hex HMAC-SHA256 of "timestamp.payload" with the webhook secret,
+// which receivers recompute to authenticate deliveries
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GetWebhookDeliveries retrieves the delivery log of a webhook, or of every webhook when webhookID is empty, newest first
+func (wh *WebhookController) GetWebhookDeliveries(webhookID string, limit int) ([]*WebhookDelivery, error) {
+	deliveries := []*WebhookDelivery{}
+
+	records, err := wh.db.d.ReadAll("webhook_deliveries")
+	if err != nil {
+		// Nothing has been delivered yet
+		return deliveries, nil
+	}
+
+	for _, record := range records {
+		var delivery WebhookDelivery
+		if err := json.Unmarshal([]byte(record), &delivery); err != nil {
+			return nil, fmt.Errorf("could not unmarshal webhook delivery: %v", err)
+		}
+		if webhookID != "" && delivery.WebhookID != webhookID {
+			continue
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].At.After(deliveries[j].At)
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// containsString reports whether a slice holds a value
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// queueEvents queues events for a webhook posting to url without going through storage
+func queueEvents(wh *WebhookController, url string, count int) {
+	webhook := Webhook{ID: "hook", URL: url, Secret: "secret", Enabled: true}
+	for i := 0; i < count; i++ {
+		wh.queue <- webhookJob{webhook: webhook, event: WebhookEvent{ID: newID(), Type: EventLocationCreated}, attempt: 1}
+	}
+}
+
+func TestWebhookRunDrainsQueue(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer server.Close()
+
+	wh := NewWebhookController(BootstrapDatabase(t.TempDir()), 8)
+	wh.Workers = 2
+	queueEvents(wh, server.URL, 5)
+
+	// Run is stopped before its workers take any event, so every event is delivered by the drain
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	wh.Run(ctx)
+
+	if got := received.Load(); got != 5 {
+		t.Errorf("received %d events, want 5", got)
+	}
+	if len(wh.queue) != 0 {
+		t.Errorf("%d events left in the queue", len(wh.queue))
+	}
+}
+
+func TestWebhookRunDrainTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	wh := NewWebhookController(BootstrapDatabase(t.TempDir()), 8)
+	wh.Workers = 1
+	wh.DrainTimeout = 50 * time.Millisecond
+	queueEvents(wh, server.URL, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stopped := make(chan struct{})
+	go func() {
+		wh.Run(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop after the drain timeout")
+	}
+	if len(wh.queue) != 2 {
+		t.Errorf("%d events left in the queue, want the 2 the drain had no time for", len(wh.queue))
+	}
+}
+
+func TestWebhookRetriesWithoutBlockingWorker(t *testing.T) {
+	var failing, healthy atomic.Int32
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer failingServer.Close()
+	healthyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthy.Add(1)
+	}))
+	defer healthyServer.Close()
+
+	wh := NewWebhookController(BootstrapDatabase(t.TempDir()), 8)
+	wh.Workers = 1
+	wh.InitialBackoff = 200 * time.Millisecond
+	queueEvents(wh, failingServer.URL, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		wh.Run(ctx)
+		close(stopped)
+	}()
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+
+	// The only worker takes the next event while the failed one waits for its backoff
+	waitFor(t, func() bool { return failing.Load() == 1 })
+	queueEvents(wh, healthyServer.URL, 1)
+	waitFor(t, func() bool { return healthy.Load() == 1 })
+	if got := failing.Load(); got != 1 {
+		t.Fatalf("failed event retried before its backoff, %d attempts", got)
+	}
+
+	waitFor(t, func() bool { return failing.Load() == 2 })
+	deliveries, err := wh.GetWebhookDeliveries("hook", 10)
+	if err != nil {
+		t.Fatalf("could not read deliveries: %v", err)
+	}
+	attempts := map[int]bool{}
+	for _, delivery := range deliveries {
+		attempts[delivery.Attempt] = true
+	}
+	if !attempts[1] || !attempts[2] {
+		t.Errorf("delivery attempts %v, want the first and its retry", attempts)
+	}
+}
+
+func TestWebhookEmitCachesWebhooks(t *testing.T) {
+	wh := NewWebhookController(BootstrapDatabase(t.TempDir()), 8)
+	webhook, err := wh.CreateWebhook(Webhook{URL: "http://localhost/hook"})
+	if err != nil {
+		t.Fatalf("could not create webhook: %v", err)
+	}
+
+	wh.Emit(EventLocationCreated, nil)
+	if len(wh.queue) != 1 {
+		t.Fatalf("%d events queued for a new webhook, want 1", len(wh.queue))
+	}
+	if _, err := wh.SetWebhookEnabled(webhook.ID, false); err != nil {
+		t.Fatalf("could not pause webhook: %v", err)
+	}
+	wh.Emit(EventLocationCreated, nil)
+	if len(wh.queue) != 1 {
+		t.Errorf("%d events queued after the webhook was paused, want 1", len(wh.queue))
+	}
+}
+
+// waitFor fails the test when the condition doesn't hold within a few seconds
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
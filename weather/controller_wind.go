@@ -0,0 +1,84 @@
+package weather
+
+import (
+	"fmt"
+	"time"
+)
+
+// WindController is Controller that estimates wind turbine production from forecast wind speeds
+type WindController struct {
+}
+
+// WindPowerForecast estimates the hourly and daily energy produced by a wind turbine at a location over the next days
+func (wp *WindController) WindPowerForecast(wc WeatherController, location Location, turbine WindTurbine, days int) (*WindPowerForecast, error) {
+	if err := turbine.validate(); err != nil {
+		return nil, fmt.Errorf("invalid turbine: %v", err)
+	}
+
+	var metrics []string
+	for _, height := range windHeights {
+		metrics = append(metrics, height.metric)
+	}
+
+	series, err := wc.FetchHourlySeries(location, metrics, days)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch wind forecast: %v", err)
+	}
+
+	forecast := &WindPowerForecast{
+		ID:           location.ID,
+		LocationName: location.Name,
+		Latitude:     location.Latitude,
+		Longitude:    location.Longitude,
+		Timezone:     series.Location.String(),
+		Turbine:      turbine,
+		Hourly:       []*WindHour{},
+		Daily:        []*WindDay{},
+		FetchedAt:    time.Now().UTC(),
+	}
+
+	var day *WindDay
+	var dayHours int
+	closeDay := func() {
+		if day != nil && dayHours > 0 {
+			day.CapacityFactor = round(day.EnergyKwh/(turbine.RatedPowerKw*float64(dayHours)), 3)
+			day.EnergyKwh = round(day.EnergyKwh, 2)
+		}
+	}
+
+	for i, at := range series.Time {
+		speeds := map[float64]float64{}
+		for _, height := range windHeights {
+			if speed, ok := series.Value(height.metric, i); ok {
+				speeds[height.height] = metresPerSecond(speed, series.Units[height.metric])
+			}
+		}
+		if len(speeds) == 0 {
+			continue
+		}
+
+		hubSpeed, exponent := hubWindSpeed(turbine.HubHeight, speeds)
+		power := turbine.power(hubSpeed)
+
+		forecast.Hourly = append(forecast.Hourly, &WindHour{
+			Time:          at.Format(time.RFC3339),
+			HubWindSpeed:  round(hubSpeed, 2),
+			ShearExponent: round(exponent, 3),
+			EnergyKwh:     round(power, 3),
+		})
+
+		date := at.Format(dateLayout)
+		if day == nil || day.Date != date {
+			closeDay()
+			day, dayHours = &WindDay{Date: date}, 0
+			forecast.Daily = append(forecast.Daily, day)
+		}
+		day.EnergyKwh += power
+		dayHours++
+		forecast.TotalKwh += power
+	}
+	closeDay()
+	forecast.TotalKwh = round(forecast.TotalKwh, 2)
+
+	return forecast, nil
+}
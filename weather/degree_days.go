@@ -0,0 +1,249 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Degree day calculation methods
+const (
+	// DegreeDayMean compares the mean of the daily maximum and minimum with the base temperature
+	DegreeDayMean = "mean"
+	// DegreeDayMetOffice uses the UK Met Office equations, which account for days crossing the base temperature
+	DegreeDayMetOffice = "metoffice"
+	// DegreeDayIntegration integrates hourly temperatures, or a sine curve between minimum and maximum without them
+	DegreeDayIntegration = "integration"
+)
+
+// Default degree day settings
+const (
+	DefaultHeatingBase = 15.5
+	DefaultCoolingBase = 22.0
+	// HeatingSeasonStart is the month heating seasons start in, seasons running until the same month of the next year
+	HeatingSeasonStart = time.October
+)
+
+// DayTemperatures holds the temperatures of a day used to compute its degree days
+type DayTemperatures struct {
+	Date   string
+	Max    float64
+	Min    float64
+	Hourly []float64
+}
+
+// DegreeDayOptions configures degree day calculations
+type DegreeDayOptions struct {
+	Method      string
+	Base        float64
+	CoolingBase float64
+}
+
+// validate checks the method is known
+func (o DegreeDayOptions) validate() error {
+	switch o.Method {
+	case DegreeDayMean, DegreeDayMetOffice, DegreeDayIntegration:
+		return nil
+	}
+	return fmt.Errorf("unsupported degree day method %q, expected %q, %q or %q", o.Method, DegreeDayMean, DegreeDayMetOffice, DegreeDayIntegration)
+}
+
+// ComputeDegreeDays computes daily degree days and their monthly aggregates
+func ComputeDegreeDays(days []DayTemperatures, options DegreeDayOptions) (*DegreeDays, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	result := &DegreeDays{
+		Method:      options.Method,
+		Base:        options.Base,
+		CoolingBase: options.CoolingBase,
+		Daily:       []*DegreeDay{},
+		Monthly:     []*DegreeDayAggregate{},
+	}
+
+	months := map[string]*DegreeDayAggregate{}
+	for _, day := range days {
+		degreeDay := &DegreeDay{
+			Date: day.Date,
+			HDD:  round(heatingDegrees(options.Method, options.Base, day), 2),
+			CDD:  round(coolingDegrees(options.Method, options.CoolingBase, day), 2),
+		}
+		result.Daily = append(result.Daily, degreeDay)
+		result.TotalHDD += degreeDay.HDD
+		result.TotalCDD += degreeDay.CDD
+
+		if len(day.Date) < len("2006-01") {
+			continue
+		}
+		month, ok := months[day.Date[:7]]
+		if !ok {
+			month = &DegreeDayAggregate{Period: day.Date[:7], Start: day.Date}
+			months[day.Date[:7]] = month
+			result.Monthly = append(result.Monthly, month)
+		}
+		month.add(degreeDay)
+	}
+
+	sort.Slice(result.Monthly, func(i, j int) bool {
+		return result.Monthly[i].Period < result.Monthly[j].Period
+	})
+	result.TotalHDD = round(result.TotalHDD, 2)
+	result.TotalCDD = round(result.TotalCDD, 2)
+
+	return result, nil
+}
+
+// add accounts a day in the aggregate
+func (a *DegreeDayAggregate) add(day *DegreeDay) {
+	if a.Start == "" || day.Date < a.Start {
+		a.Start = day.Date
+	}
+	if day.Date > a.End {
+		a.End = day.Date
+	}
+	a.Days++
+	a.HDD = round(a.HDD+day.HDD, 2)
+	a.CDD = round(a.CDD+day.CDD, 2)
+}
+
+// heatingDegrees computes the heating degree days of a day
+func heatingDegrees(method string, base float64, day DayTemperatures) float64 {
+	mean := (day.Max + day.Min) / 2
+
+	switch method {
+	case DegreeDayMetOffice:
+		switch {
+		case day.Max <= base:
+			return base - mean
+		case mean < base:
+			return (base-day.Min)/2 - (day.Max-base)/4
+		case day.Min < base:
+			return (base - day.Min) / 4
+		default:
+			return 0
+		}
+
+	case DegreeDayIntegration:
+		temperatures := day.Hourly
+		if len(temperatures) == 0 {
+			temperatures = sineDay(day.Min, day.Max, 96)
+		}
+		var sum float64
+		for _, temperature := range temperatures {
+			sum += math.Max(0, base-temperature)
+		}
+		return sum / float64(len(temperatures))
+
+	default:
+		return math.Max(0, base-mean)
+	}
+}
+
+// coolingDegrees computes the cooling degree days of a day, mirroring the heating equations
+func coolingDegrees(method string, base float64, day DayTemperatures) float64 {
+	mirrored := DayTemperatures{Date: day.Date, Max: -day.Min, Min: -day.Max}
+	for _, temperature := range day.Hourly {
+		mirrored.Hourly = append(mirrored.Hourly, -temperature)
+	}
+	return heatingDegrees(method, -base, mirrored)
+}
+
+// sineDay approximates the temperatures of a day by a sine curve between its minimum and maximum
+func sineDay(min, max float64, samples int) []float64 {
+	mean, amplitude := (max+min)/2, (max-min)/2
+	temperatures := make([]float64, samples)
+	for i := range temperatures {
+		temperatures[i] = mean + amplitude*math.Sin(2*math.Pi*(float64(i)+0.5)/float64(samples))
+	}
+	return temperatures
+}
+
+// round rounds a value to the given number of decimals
+func round(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
+
+// seasonStart returns the first day of the heating season the given date belongs to
+func seasonStart(date time.Time) time.Time {
+	year := date.Year()
+	if date.Month() < HeatingSeasonStart {
+		year--
+	}
+	return time.Date(year, HeatingSeasonStart, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// dailyTemperatures collects the days having both a maximum and a minimum temperature, with their hourly values if any
+func dailyTemperatures(daily DailyData, hourly HourlyData) []DayTemperatures {
+	hours := hourlyByDate(hourly)
+
+	var days []DayTemperatures
+	for i, date := range daily.Time {
+		if i >= len(daily.Temperature2mMax) || i >= len(daily.Temperature2mMin) {
+			break
+		}
+		if math.IsNaN(daily.Temperature2mMax[i]) || math.IsNaN(daily.Temperature2mMin[i]) {
+			continue
+		}
+		days = append(days, DayTemperatures{
+			Date:   date,
+			Max:    daily.Temperature2mMax[i],
+			Min:    daily.Temperature2mMin[i],
+			Hourly: hours[date],
+		})
+	}
+	return days
+}
+
+// hourlyTemperatures builds the days of hourly data, taking their extremes from the hourly values
+func hourlyTemperatures(hourly HourlyData) []DayTemperatures {
+	hours := hourlyByDate(hourly)
+
+	var dates []string
+	for date := range hours {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	var days []DayTemperatures
+	for _, date := range dates {
+		day := DayTemperatures{Date: date, Max: math.Inf(-1), Min: math.Inf(1), Hourly: hours[date]}
+		for _, temperature := range day.Hourly {
+			day.Max = math.Max(day.Max, temperature)
+			day.Min = math.Min(day.Min, temperature)
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// hourlyByDate groups hourly temperatures by date, leaving out missing ones
+func hourlyByDate(hourly HourlyData) map[string][]float64 {
+	hours := map[string][]float64{}
+	for i, step := range hourly.Time {
+		if i >= len(hourly.Temperature2m) || len(step) < len(dateLayout) {
+			break
+		}
+		if math.IsNaN(hourly.Temperature2m[i]) {
+			continue
+		}
+		date := step[:len(dateLayout)]
+		hours[date] = append(hours[date], hourly.Temperature2m[i])
+	}
+	return hours
+}
+
+// observedTemperatures collects the days of an archive series having both a maximum and a minimum temperature
+func observedTemperatures(series *ArchiveSeries) []DayTemperatures {
+	var days []DayTemperatures
+	maxima, minima := series.Values["temperature_2m_max"], series.Values["temperature_2m_min"]
+	for i, date := range series.Time {
+		if i >= len(maxima) || i >= len(minima) || maxima[i] == nil || minima[i] == nil {
+			continue
+		}
+		days = append(days, DayTemperatures{Date: date, Max: *maxima[i], Min: *minima[i]})
+	}
+	return days
+}
@@ -0,0 +1,75 @@
+package weather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeatingAndCoolingDegrees(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		day     DayTemperatures
+		wantHDD float64
+		wantCDD float64
+	}{
+		{"mean below the base", DegreeDayMean, DayTemperatures{Max: 10, Min: 4}, 8.5, 0},
+		{"mean between the bases", DegreeDayMean, DayTemperatures{Max: 20, Min: 14}, 0, 0},
+		{"mean above the cooling base", DegreeDayMean, DayTemperatures{Max: 30, Min: 20}, 0, 3},
+		// Met Office heating: 15.5 - 7
+		{"Met Office maximum below the base", DegreeDayMetOffice, DayTemperatures{Max: 10, Min: 4}, 8.5, 0},
+		// (15.5 - 8) / 2 - (18 - 15.5) / 4
+		{"Met Office mean below the base", DegreeDayMetOffice, DayTemperatures{Max: 18, Min: 8}, 3.125, 0},
+		// (15.5 - 10) / 4
+		{"Met Office mean above the base", DegreeDayMetOffice, DayTemperatures{Max: 24, Min: 10}, 1.375, 0.5},
+		{"Met Office minimum above the base", DegreeDayMetOffice, DayTemperatures{Max: 25, Min: 16}, 0, 0.75},
+		// Met Office cooling: (30 - 22) / 2 - (22 - 18) / 4
+		{"Met Office mean above the cooling base", DegreeDayMetOffice, DayTemperatures{Max: 30, Min: 18}, 0, 3},
+		// (5.5 + 1.5) / 4 and (2 + 4) / 4
+		{"hourly integration", DegreeDayIntegration, DayTemperatures{Max: 26, Min: 10, Hourly: []float64{10, 14, 24, 26}}, 1.75, 1.5},
+		// The sine curve averages to its mean when it stays on one side of the base
+		{"sine integration below the base", DegreeDayIntegration, DayTemperatures{Max: 15, Min: 5}, 5.5, 0},
+		// Half a sine wave of amplitude 5 below the base, 5/π
+		{"sine integration around the base", DegreeDayIntegration, DayTemperatures{Max: 20.5, Min: 10.5}, 5 / math.Pi, 0},
+	}
+	for _, test := range tests {
+		if got := heatingDegrees(test.method, DefaultHeatingBase, test.day); math.Abs(got-test.wantHDD) > 1e-3 {
+			t.Errorf("%s: heatingDegrees = %v, want %v", test.name, got, test.wantHDD)
+		}
+		if got := coolingDegrees(test.method, DefaultCoolingBase, test.day); math.Abs(got-test.wantCDD) > 1e-3 {
+			t.Errorf("%s: coolingDegrees = %v, want %v", test.name, got, test.wantCDD)
+		}
+	}
+}
+
+func TestComputeDegreeDaysMonthly(t *testing.T) {
+	days := []DayTemperatures{
+		{Date: "2024-01-31", Max: 6, Min: 2},
+		{Date: "2024-02-01", Max: 20, Min: 14},
+		{Date: "2024-01-30", Max: 10, Min: 4},
+	}
+	result, err := ComputeDegreeDays(days, DegreeDayOptions{Method: DegreeDayMean, Base: DefaultHeatingBase, CoolingBase: DefaultCoolingBase})
+	if err != nil {
+		t.Fatalf("ComputeDegreeDays failed: %v", err)
+	}
+
+	want := []DegreeDayAggregate{
+		{Period: "2024-01", Start: "2024-01-30", End: "2024-01-31", Days: 2, HDD: 20},
+		{Period: "2024-02", Start: "2024-02-01", End: "2024-02-01", Days: 1},
+	}
+	if len(result.Monthly) != len(want) {
+		t.Fatalf("ComputeDegreeDays returned %d months, want %d", len(result.Monthly), len(want))
+	}
+	for i, month := range result.Monthly {
+		if *month != want[i] {
+			t.Errorf("month %d = %+v, want %+v", i, *month, want[i])
+		}
+	}
+	if result.TotalHDD != 20 || result.TotalCDD != 0 {
+		t.Errorf("totals = %v HDD and %v CDD, want 20 and 0", result.TotalHDD, result.TotalCDD)
+	}
+
+	if _, err := ComputeDegreeDays(days, DegreeDayOptions{Method: "median"}); err == nil {
+		t.Error("ComputeDegreeDays accepted an unknown method")
+	}
+}
@@ -0,0 +1,65 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Default heat pump model settings
+const (
+	// carnotEfficiency is the share of the Carnot COP reached by typical air-source heat pumps
+	carnotEfficiency = 0.45
+	// minCOP and maxCOP bound the Carnot-based COP to what real heat pumps achieve
+	minCOP = 1.0
+	maxCOP = 7.0
+)
+
+// validate checks a building is physically meaningful and replaces its COP curve with a sorted copy
+func (building *Building) validate() error {
+	if building.HeatLossCoefficient <= 0 {
+		return fmt.Errorf("heat loss coefficient must be positive")
+	}
+	if building.FlowTemperature <= building.Setpoint && len(building.COPCurve) == 0 {
+		return fmt.Errorf("flow temperature must be above the setpoint")
+	}
+	for _, point := range building.COPCurve {
+		if point.COP < 1 {
+			return fmt.Errorf("COP curve values must be at least 1")
+		}
+	}
+	// Sort a copy, the curve may be shared with the caller
+	curve := append([]COPPoint(nil), building.COPCurve...)
+	sort.Slice(curve, func(i, j int) bool {
+		return curve[i].OutdoorTemperature < curve[j].OutdoorTemperature
+	})
+	building.COPCurve = curve
+	return nil
+}
+
+// heatLoad returns the heating power in kW needed to hold the setpoint at an outdoor temperature
+func (building *Building) heatLoad(outdoor float64) float64 {
+	return math.Max(0, building.HeatLossCoefficient*(building.Setpoint-outdoor)/1000)
+}
+
+// cop returns the coefficient of performance of the heat pump at an outdoor temperature, interpolating the
+// tabulated curve linearly and holding its end values, or from the Carnot limit without a curve
+func (building *Building) cop(outdoor float64) float64 {
+	curve := building.COPCurve
+	if len(curve) == 0 {
+		flow := building.FlowTemperature + 273.15
+		lift := math.Max(1, building.FlowTemperature-outdoor)
+		return math.Max(minCOP, math.Min(maxCOP, carnotEfficiency*flow/lift))
+	}
+
+	if outdoor <= curve[0].OutdoorTemperature {
+		return curve[0].COP
+	}
+	for i := 1; i < len(curve); i++ {
+		if outdoor <= curve[i].OutdoorTemperature {
+			ratio := (outdoor - curve[i-1].OutdoorTemperature) / (curve[i].OutdoorTemperature - curve[i-1].OutdoorTemperature)
+			return curve[i-1].COP + ratio*(curve[i].COP-curve[i-1].COP)
+		}
+	}
+	return curve[len(curve)-1].COP
+}
@@ -0,0 +1,64 @@
+package weather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuildingCOP(t *testing.T) {
+	carnot := &Building{HeatLossCoefficient: 200, Setpoint: 20, FlowTemperature: 35}
+	curve := []COPPoint{{OutdoorTemperature: 7, COP: 4.0}, {OutdoorTemperature: -7, COP: 2.5}, {OutdoorTemperature: 2, COP: 3.4}}
+	tabulated := &Building{HeatLossCoefficient: 200, Setpoint: 20, FlowTemperature: 35, COPCurve: curve}
+	for _, building := range []*Building{carnot, tabulated} {
+		if err := building.validate(); err != nil {
+			t.Fatalf("validate failed: %v", err)
+		}
+	}
+	if curve[0].OutdoorTemperature != 7 {
+		t.Errorf("validate sorted the caller's curve: %v", curve)
+	}
+
+	tests := []struct {
+		name     string
+		building *Building
+		outdoor  float64
+		want     float64
+	}{
+		// 45% of the Carnot COP 308.15 K / 35 K
+		{"Carnot", carnot, 0, 3.9619},
+		{"Carnot clamped to the minimum", carnot, -250, minCOP},
+		{"Carnot clamped to the maximum", carnot, 30, maxCOP},
+		{"Carnot lift of at least 1 K", carnot, 40, maxCOP},
+		{"before the curve", tabulated, -10, 2.5},
+		{"first point", tabulated, -7, 2.5},
+		{"between the first points", tabulated, -2.5, 2.95},
+		{"middle point", tabulated, 2, 3.4},
+		{"between the last points", tabulated, 4.5, 3.7},
+		{"last point", tabulated, 7, 4.0},
+		{"past the curve", tabulated, 15, 4.0},
+	}
+	for _, test := range tests {
+		if got := test.building.cop(test.outdoor); math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("%s: cop(%v) = %v, want %v", test.name, test.outdoor, got, test.want)
+		}
+	}
+}
+
+func TestBuildingHeatLoad(t *testing.T) {
+	building := &Building{HeatLossCoefficient: 200, Setpoint: 20}
+	tests := []struct {
+		outdoor float64
+		want    float64
+	}{
+		{-10, 6},
+		{12.5, 1.5},
+		{20, 0},
+		// No cooling load above the setpoint
+		{25, 0},
+	}
+	for _, test := range tests {
+		if got := building.heatLoad(test.outdoor); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("heatLoad(%v) = %v, want %v", test.outdoor, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package weather
+
+import (
+	"strings"
+	"sync"
+)
+
+// weatherLoad is the memoized result of loading the weather of a location
+type weatherLoad struct {
+	done     bool
+	current  *CurrentWeatherInfo
+	forecast *WeatherForecastInfo
+	err      error
+}
+
+// WeatherLoader batches and memoizes the weather loads of a single GraphQL operation. Loads return thunks, which
+// graphql-go calls once every sibling field was resolved, so the current weather of all locations selected in an
+// operation is loaded with a single LatestCurrentWeather call, and their forecasts with one LatestWeatherForecasts
+// call per set of metrics.
+type WeatherLoader struct {
+	db Database
+	wc WeatherController
+
+	mu        sync.Mutex
+	pending   []Location
+	current   map[string]*weatherLoad
+	forecasts map[string]*weatherLoad
+	// pendingForecasts are the locations whose forecast is queued, by metrics
+	pendingForecasts map[string][]Location
+}
+
+// NewWeatherLoader creates a loader for one GraphQL operation
+func NewWeatherLoader(db Database, wc WeatherController) *WeatherLoader {
+	return &WeatherLoader{
+		db:        db,
+		wc:        wc,
+		current:   map[string]*weatherLoad{},
+		forecasts: map[string]*weatherLoad{},
+
+		pendingForecasts: map[string][]Location{},
+	}
+}
+
+// LoadCurrent queues the current weather of a location and returns a thunk that loads every queued location on its
+// first call. The weather is nil when none could be found for the location.
+func (wl *WeatherLoader) LoadCurrent(location Location) func() (*CurrentWeatherInfo, error) {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	load, ok := wl.current[location.ID]
+	if !ok {
+		load = &weatherLoad{}
+		wl.current[location.ID] = load
+		wl.pending = append(wl.pending, location)
+	}
+
+	return func() (*CurrentWeatherInfo, error) {
+		wl.mu.Lock()
+		defer wl.mu.Unlock()
+
+		if !load.done {
+			wl.dispatch()
+		}
+		return load.current, load.err
+	}
+}
+
+// dispatch loads the current weather of every queued location in one call
+func (wl *WeatherLoader) dispatch() {
+	locations := wl.pending
+	wl.pending = nil
+
+	infos, err := wl.wc.LatestCurrentWeather(wl.db, locations)
+	for i, location := range locations {
+		load := wl.current[location.ID]
+		load.done, load.err = true, err
+		if err == nil {
+			load.current = infos[i]
+		}
+	}
+}
+
+// LoadForecast queues the forecast of a location and returns a thunk that loads every location queued with the same
+// metrics on its first call. Forecasts are memoized by location and metrics.
+func (wl *WeatherLoader) LoadForecast(location Location, metrics []string) func() (*WeatherForecastInfo, error) {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	metricsKey := strings.Join(metrics, ",")
+	load, ok := wl.forecasts[forecastLoadKey(location.ID, metricsKey)]
+	if !ok {
+		load = &weatherLoad{}
+		wl.forecasts[forecastLoadKey(location.ID, metricsKey)] = load
+		wl.pendingForecasts[metricsKey] = append(wl.pendingForecasts[metricsKey], location)
+	}
+
+	return func() (*WeatherForecastInfo, error) {
+		wl.mu.Lock()
+		defer wl.mu.Unlock()
+
+		if !load.done {
+			wl.dispatchForecasts(metrics)
+		}
+		return load.forecast, load.err
+	}
+}
+
+// forecastLoadKey keys the forecast load of a location and metrics
+func forecastLoadKey(locationID, metricsKey string) string {
+	return locationID + "|" + metricsKey
+}
+
+// dispatchForecasts loads the forecasts of every location queued with the given metrics in one call
+func (wl *WeatherLoader) dispatchForecasts(metrics []string) {
+	metricsKey := strings.Join(metrics, ",")
+	locations := wl.pendingForecasts[metricsKey]
+	delete(wl.pendingForecasts, metricsKey)
+
+	forecasts, err := wl.wc.LatestWeatherForecasts(wl.db, locations, metrics)
+	for i, location := range locations {
+		load := wl.forecasts[forecastLoadKey(location.ID, metricsKey)]
+		load.done, load.err = true, err
+		if err == nil {
+			load.forecast = forecasts[i]
+		}
+	}
+}
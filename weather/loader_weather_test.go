@@ -0,0 +1,59 @@
+package weather
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWeatherLoaderBatchesForecasts(t *testing.T) {
+	var requests atomic.Int32
+	var latitudes atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		latitudes.Store(r.URL.Query().Get("latitude"))
+		w.Write([]byte(`[
+			{"daily": {"time": ["2024-05-01"], "temperature_2m_max": [21.2]}},
+			{"daily": {"time": ["2024-05-01"], "temperature_2m_max": [24.8]}}
+		]`))
+	}))
+	defer server.Close()
+
+	db := BootstrapDatabase(t.TempDir())
+	lc := &LocationController{}
+	berlin := Location{Name: "Berlin", Latitude: "52.5200", Longitude: "13.4050"}
+	paris := Location{Name: "Paris", Latitude: "48.8566", Longitude: "2.3522"}
+	for _, location := range []*Location{&berlin, &paris} {
+		if err := lc.AddLocation(db, *location); err != nil {
+			t.Fatalf("could not add location: %v", err)
+		}
+		location.ID = GenerateID(location.Latitude, location.Longitude)
+	}
+
+	wl := NewWeatherLoader(db, WeatherController{ForecastURL: server.URL})
+	metrics := []string{"temperature_2m_max"}
+	loadBerlin := wl.LoadForecast(berlin, metrics)
+	loadParis := wl.LoadForecast(paris, metrics)
+	loadBerlinAgain := wl.LoadForecast(berlin, metrics)
+
+	forecasts := map[string]func() (*WeatherForecastInfo, error){"Berlin": loadBerlin, "Paris": loadParis, "Berlin again": loadBerlinAgain}
+	want := map[string]float64{"Berlin": 21.2, "Paris": 24.8, "Berlin again": 21.2}
+	for name, load := range forecasts {
+		forecast, err := load()
+		if err != nil {
+			t.Fatalf("could not load the forecast of %s: %v", name, err)
+		}
+		if got := forecast.Daily.Temperature2mMax; len(got) != 1 || got[0] != want[name] {
+			t.Errorf("forecast of %s = %v, want [%v]", name, got, want[name])
+		}
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+	if got := latitudes.Load(); got != strings.Join([]string{berlin.Latitude, paris.Latitude}, ",") {
+		t.Errorf("requested latitudes %v, want both locations", got)
+	}
+}
@@ -0,0 +1,231 @@
+package weather
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/chafikchaban/greenheat-backend/metrics"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Metrics of the weather service, served by /metrics
+var (
+	upstreamRequests = metrics.Default.NewCounterVec("greenheat_upstream_requests_total",
+		"Requests sent to weather providers, by provider and status code, error for transport errors", "provider", "code")
+	upstreamDuration = metrics.Default.NewHistogramVec("greenheat_upstream_request_duration_seconds",
+		"Time taken by weather providers to respond, by provider", metrics.DefaultBuckets, "provider")
+	upstreamBackoffs = metrics.Default.NewCounterVec("greenheat_upstream_rate_limit_backoffs_total",
+		"Pauses of background refreshes after a provider rate limited them, by provider", "provider")
+
+	cacheRequests = metrics.Default.NewCounterVec("greenheat_cache_requests_total",
+		"Weather requests served from the cache or fetched, by cache and result", "cache", "result")
+	_ = metrics.Default.NewGaugeFunc("greenheat_snapshot_cache_hit_ratio",
+		"Share of weather requests served from snapshots since the server started", func() float64 {
+			hits, misses := SnapshotCache.Counts()
+			if hits+misses == 0 {
+				return 0
+			}
+			return float64(hits) / float64(hits+misses)
+		})
+
+	storageDuration = metrics.Default.NewHistogramVec("greenheat_storage_operation_duration_seconds",
+		"Time taken by scribble operations, by operation and collection", metrics.DefaultBuckets, "operation", "collection")
+
+	graphqlOperations = metrics.Default.NewCounterVec("greenheat_graphql_operations_total",
+		"GraphQL operations executed, by operation name, type and result", "operation", "type", "result")
+	graphqlDuration = metrics.Default.NewHistogramVec("greenheat_graphql_operation_duration_seconds",
+		"Time taken to execute GraphQL operations, by operation name and type", metrics.DefaultBuckets, "operation", "type")
+	graphqlFieldDuration = metrics.Default.NewHistogramVec("greenheat_graphql_field_duration_seconds",
+		"Time taken by GraphQL resolvers, by field", metrics.DefaultBuckets, "field")
+	graphqlFieldErrors = metrics.Default.NewCounterVec("greenheat_graphql_field_errors_total",
+		"Errors returned by GraphQL resolvers, by field", "field")
+)
+
+// RegisterLocationGauges adds gauges of the tracked locations to a registry, read from storage on every scrape
+func RegisterLocationGauges(registry *metrics.Registry, db Database, lc LocationController, wc WeatherController) {
+	registry.NewGaugeFunc("greenheat_locations", "Locations tracked", func() float64 {
+		locations, err := lc.GetLocations(db)
+		if err != nil {
+			return 0
+		}
+		return float64(len(locations))
+	})
+	registry.NewGaugeFunc("greenheat_locations_fresh", "Locations whose current weather snapshot may be served", func() float64 {
+		snapshots, err := wc.GetCurrentSnapshots(db)
+		if err != nil {
+			return 0
+		}
+		var fresh int
+		for _, snapshot := range snapshots {
+			if wc.isFresh(snapshot.FetchedAt) {
+				fresh++
+			}
+		}
+		return float64(fresh)
+	})
+}
+
+// operationMetrics is what MetricsExtension learns about an operation while it runs
+type operationMetrics struct {
+	start         time.Time
+	name          string
+	operationType string
+}
+
+// maxOperationNames is how many operation names get a series of their own. Names are chosen by clients, so later
+// ones are counted as "other" to bound the series.
+const maxOperationNames = 64
+
+// operationName matches the names GraphQL allows
+var operationName = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// operationNames are the operation names that have a series
+var operationNames = struct {
+	sync.Mutex
+	known map[string]bool
+}{known: map[string]bool{}}
+
+// operationLabel returns the label of an operation name: the name itself once it has a series or while there is
+// room for one, "other" for the rest and for invalid names
+func operationLabel(name string) string {
+	if !operationName.MatchString(name) {
+		return "other"
+	}
+	operationNames.Lock()
+	defer operationNames.Unlock()
+	if !operationNames.known[name] {
+		if len(operationNames.known) >= maxOperationNames {
+			return "other"
+		}
+		operationNames.known[name] = true
+	}
+	return name
+}
+
+// labels returns the operation name and type, "anonymous" and "unknown" when they are not known
+func (op *operationMetrics) labels() (string, string) {
+	name, operationType := op.name, op.operationType
+	if name == "" {
+		name = "anonymous"
+	} else {
+		name = operationLabel(name)
+	}
+	if operationType == "" {
+		operationType = "unknown"
+	}
+	return name, operationType
+}
+
+// record counts and times the operation
+func (op *operationMetrics) record(result string) {
+	name, operationType := op.labels()
+	graphqlOperations.Inc(name, operationType, result)
+	graphqlDuration.Observe(time.Since(op.start).Seconds(), name, operationType)
+}
+
+// MetricsExtension counts and times GraphQL operations by name. Fields are timed by timedResolver, as the extension
+// is told a field finished before the thunks of batched fields run.
+type MetricsExtension struct{}
+
+var _ graphql.Extension = MetricsExtension{}
+
+// Init starts timing an operation
+func (MetricsExtension) Init(ctx context.Context, params *graphql.Params) context.Context {
+	return context.WithValue(ctx, operationMetricsKey, &operationMetrics{start: time.Now(), name: params.OperationName})
+}
+
+// Name names the extension in errors
+func (MetricsExtension) Name() string {
+	return "metrics"
+}
+
+// ParseDidStart records operations that could not be parsed
+func (MetricsExtension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(err error) {
+		if op, ok := ctx.Value(operationMetricsKey).(*operationMetrics); ok && err != nil {
+			op.record("invalid")
+		}
+	}
+}
+
+// ValidationDidStart records operations that are not valid against the schema
+func (MetricsExtension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func(errs []gqlerrors.FormattedError) {
+		if op, ok := ctx.Value(operationMetricsKey).(*operationMetrics); ok && len(errs) > 0 {
+			op.record("invalid")
+		}
+	}
+}
+
+// ExecutionDidStart records executed operations once they finished
+func (MetricsExtension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	op, ok := ctx.Value(operationMetricsKey).(*operationMetrics)
+	if !ok {
+		// Executed without going through graphql.Do
+		op = &operationMetrics{start: time.Now()}
+		ctx = context.WithValue(ctx, operationMetricsKey, op)
+	}
+	return ctx, func(result *graphql.Result) {
+		if result.HasErrors() {
+			op.record("error")
+		} else {
+			op.record("ok")
+		}
+	}
+}
+
+// ResolveFieldDidStart learns the operation name and type from the first field
+func (MetricsExtension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	if op, ok := ctx.Value(operationMetricsKey).(*operationMetrics); ok && op.operationType == "" {
+		if operation, ok := info.Operation.(*ast.OperationDefinition); ok {
+			op.operationType = operation.Operation
+			if op.name == "" && operation.Name != nil {
+				op.name = operation.Name.Value
+			}
+		}
+	}
+	return ctx, func(interface{}, error) {}
+}
+
+// HasResult reports that the extension adds nothing to results
+func (MetricsExtension) HasResult() bool {
+	return false
+}
+
+// GetResult adds nothing to results
+func (MetricsExtension) GetResult(context.Context) interface{} {
+	return nil
+}
+
+// timedResolver times a field resolver and counts its errors. A resolver returning a thunk, as batched fields do, is
+// timed until the thunk returns, and counted with the error of the thunk.
+func timedResolver(field string, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		return nil
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		start := time.Now()
+		result, err := resolve(p)
+		if thunk, ok := result.(func() (interface{}, error)); ok && err == nil {
+			return func() (interface{}, error) {
+				result, err := thunk()
+				observeField(field, start, err)
+				return result, err
+			}, nil
+		}
+		observeField(field, start, err)
+		return result, err
+	}
+}
+
+// observeField records how long a field took to resolve and whether it failed
+func observeField(field string, start time.Time, err error) {
+	graphqlFieldDuration.Observe(time.Since(start).Seconds(), field)
+	if err != nil {
+		graphqlFieldErrors.Inc(field)
+	}
+}
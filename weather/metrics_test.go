@@ -0,0 +1,103 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chafikchaban/greenheat-backend/metrics"
+	"github.com/graphql-go/graphql"
+)
+
+// sample returns the value of a series served by the default registry, or 0 when there is none
+func sample(series string) float64 {
+	var b strings.Builder
+	metrics.Default.Write(&b)
+	for _, line := range strings.Split(b.String(), "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			v, _ := strconv.ParseFloat(value, 64)
+			return v
+		}
+	}
+	return 0
+}
+
+func TestTimedResolverWaitsForThunks(t *testing.T) {
+	count := `greenheat_graphql_field_duration_seconds_count{field="Test.batched"}`
+	sum := `greenheat_graphql_field_duration_seconds_sum{field="Test.batched"}`
+	errs := `greenheat_graphql_field_errors_total{field="Test.batched"}`
+	countBefore, sumBefore, errsBefore := sample(count), sample(sum), sample(errs)
+
+	failure := errors.New("upstream failed")
+	resolve := timedResolver("Test.batched", func(p graphql.ResolveParams) (interface{}, error) {
+		return func() (interface{}, error) {
+			time.Sleep(20 * time.Millisecond)
+			return nil, failure
+		}, nil
+	})
+
+	result, err := resolve(graphql.ResolveParams{})
+	if err != nil {
+		t.Fatalf("resolver failed before its thunk ran: %v", err)
+	}
+	if sample(count) != countBefore {
+		t.Fatalf("field was timed before its thunk ran")
+	}
+	thunk, ok := result.(func() (interface{}, error))
+	if !ok {
+		t.Fatalf("resolver returned %T, want a thunk", result)
+	}
+	if _, err := thunk(); err != failure {
+		t.Errorf("thunk returned %v, want %v", err, failure)
+	}
+
+	if took := sample(sum) - sumBefore; took < 0.02 {
+		t.Errorf("field timed at %vs, want the 0.02s the thunk took", took)
+	}
+	if got := sample(errs) - errsBefore; got != 1 {
+		t.Errorf("field errors = %v, want 1", got)
+	}
+}
+
+func TestTimedResolverCountsErrors(t *testing.T) {
+	count := `greenheat_graphql_field_duration_seconds_count{field="Test.plain"}`
+	errs := `greenheat_graphql_field_errors_total{field="Test.plain"}`
+	countBefore, errsBefore := sample(count), sample(errs)
+
+	resolve := timedResolver("Test.plain", func(p graphql.ResolveParams) (interface{}, error) {
+		return nil, errors.New("not found")
+	})
+	resolve(graphql.ResolveParams{})
+
+	if got := sample(count) - countBefore; got != 1 {
+		t.Errorf("field timed %v times, want 1", got)
+	}
+	if got := sample(errs) - errsBefore; got != 1 {
+		t.Errorf("field errors = %v, want 1", got)
+	}
+}
+
+func TestOperationLabel(t *testing.T) {
+	operationNames.Lock()
+	operationNames.known = map[string]bool{}
+	operationNames.Unlock()
+
+	for i := 0; i < maxOperationNames; i++ {
+		name := fmt.Sprintf("Operation%d", i)
+		if got := operationLabel(name); got != name {
+			t.Fatalf("operationLabel(%s) = %s, want the name", name, got)
+		}
+	}
+	if got := operationLabel("OneTooMany"); got != "other" {
+		t.Errorf("operationLabel past the cap = %s, want other", got)
+	}
+	if got := operationLabel("Operation0"); got != "Operation0" {
+		t.Errorf("operationLabel of a known name past the cap = %s, want Operation0", got)
+	}
+	if got := operationLabel(`x"} 1`); got != "other" {
+		t.Errorf("operationLabel of an invalid name = %s, want other", got)
+	}
+}
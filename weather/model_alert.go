@@ -0,0 +1,61 @@
+package weather
+
+import "time"
+
+// AlertRule represents a condition on a forecast metric that notifies when it is expected to be met
+type AlertRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// LocationIDs is the group of locations the rule watches, every location when empty
+	LocationIDs []string `json:"location_ids"`
+	// Metric is the Open-Meteo name of a daily or hourly forecast metric, compared in metric units
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+	// HorizonHours is how far ahead the forecast is watched
+	HorizonHours int `json:"horizon_hours"`
+	// CooldownMinutes is the minimum time between two alerts of the rule for a location
+	CooldownMinutes int `json:"cooldown_minutes"`
+	// Channels are the notification sinks alerts are sent to: webhook, email or feed
+	Channels   []string  `json:"channels"`
+	WebhookURL string    `json:"webhook_url"`
+	Email      string    `json:"email"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// AlertDelivery represents the outcome of sending an alert to a channel
+type AlertDelivery struct {
+	Channel string    `json:"channel"`
+	Status  string    `json:"status"`
+	Error   string    `json:"error"`
+	At      time.Time `json:"at"`
+}
+
+// Alert represents a rule that was met by the forecast of a location
+type Alert struct {
+	ID           string  `json:"id"`
+	RuleID       string  `json:"rule_id"`
+	RuleName     string  `json:"rule_name"`
+	LocationID   string  `json:"location_id"`
+	LocationName string  `json:"location_name"`
+	Metric       string  `json:"metric"`
+	Operator     string  `json:"operator"`
+	Threshold    float64 `json:"threshold"`
+	Value        float64 `json:"value"`
+	Unit         string  `json:"unit"`
+	// ForecastTime is the local hour or date at which the forecast first meets the rule
+	ForecastTime string          `json:"forecast_time"`
+	Message      string          `json:"message"`
+	TriggeredAt  time.Time       `json:"triggered_at"`
+	Deliveries   []AlertDelivery `json:"deliveries"`
+	Acknowledged bool            `json:"acknowledged"`
+}
+
+// AlertState remembers what a rule last notified for a location, to deduplicate alerts and apply cooldowns
+type AlertState struct {
+	LastTriggeredAt time.Time `json:"last_triggered_at"`
+	// Notified holds the forecast times already alerted on
+	Notified []string `json:"notified"`
+}
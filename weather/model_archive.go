@@ -0,0 +1,23 @@
+package weather
+
+// ArchiveSeries represents raw observed values returned by an archive provider, one entry per time step
+type ArchiveSeries struct {
+	Time   []string              `json:"time"`
+	Values map[string][]*float64 `json:"values"`
+	Units  map[string]string     `json:"units"`
+}
+
+// HistoricalWeather represents the observed weather data returned from the historicalWeather query
+type HistoricalWeather struct {
+	ID           string     `json:"id"`
+	LocationName string     `json:"location_name"`
+	Latitude     string     `json:"latitude"`
+	Longitude    string     `json:"longitude"`
+	Start        string     `json:"start"`
+	End          string     `json:"end"`
+	Resolution   string     `json:"resolution"`
+	HourlyUnits  Units      `json:"hourly_units"`
+	Hourly       HourlyData `json:"hourly"`
+	DailyUnits   Units      `json:"daily_units"`
+	Daily        DailyData  `json:"daily"`
+}
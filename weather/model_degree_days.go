@@ -0,0 +1,40 @@
+package weather
+
+// DegreeDay represents the heating and cooling degree days of a single day
+type DegreeDay struct {
+	Date string  `json:"date"`
+	HDD  float64 `json:"hdd"`
+	CDD  float64 `json:"cdd"`
+}
+
+// DegreeDayAggregate represents degree days summed over a period, such as a month or a heating season
+type DegreeDayAggregate struct {
+	Period string  `json:"period"`
+	Start  string  `json:"start"`
+	End    string  `json:"end"`
+	Days   int     `json:"days"`
+	HDD    float64 `json:"hdd"`
+	CDD    float64 `json:"cdd"`
+}
+
+// DegreeDays represents the degree days of a forecast or of observed weather
+type DegreeDays struct {
+	Method      string                `json:"method"`
+	Base        float64               `json:"base"`
+	CoolingBase float64               `json:"cooling_base"`
+	Daily       []*DegreeDay          `json:"daily"`
+	Monthly     []*DegreeDayAggregate `json:"monthly"`
+	TotalHDD    float64               `json:"total_hdd"`
+	TotalCDD    float64               `json:"total_cdd"`
+
+	// seasonToDate computes the aggregate since the start of the heating season on demand, as it needs observed weather
+	seasonToDate func() (*DegreeDayAggregate, error)
+}
+
+// SeasonToDate returns the degree days since the start of the heating season up to the last day of the data
+func (d *DegreeDays) SeasonToDate() (*DegreeDayAggregate, error) {
+	if d.seasonToDate == nil {
+		return nil, nil
+	}
+	return d.seasonToDate()
+}
@@ -0,0 +1,75 @@
+package weather
+
+import "math"
+
+// DailyForecast represents the forecast of a single day. Values missing from the forecast are nil.
+type DailyForecast struct {
+	Date             string
+	TemperatureMax   *float64
+	TemperatureMin   *float64
+	WindSpeedMax     *float64
+	WindDirection    *int
+	UvIndexMax       *float64
+	WeatherCode      *int
+	PrecipitationSum *float64
+	windSpeedUnit    string
+}
+
+// HourlyForecast represents the forecast of a single hour. Values missing from the forecast are nil.
+type HourlyForecast struct {
+	Time        string
+	Temperature *float64
+	CloudCover  *int
+	WindSpeed   *float64
+	UvIndex     *float64
+}
+
+// Days turns the parallel daily series into one entry per day, leaving values of shorter series nil
+func (d DailyData) Days() []*DailyForecast {
+	days := make([]*DailyForecast, len(d.Time))
+	for i, date := range d.Time {
+		days[i] = &DailyForecast{
+			Date:             date,
+			TemperatureMax:   floatAt(d.Temperature2mMax, i),
+			TemperatureMin:   floatAt(d.Temperature2mMin, i),
+			WindSpeedMax:     floatAt(d.WindSpeed10mMax, i),
+			WindDirection:    intAt(d.WindDirectionAngle, i),
+			UvIndexMax:       floatAt(d.UvIndexMax, i),
+			WeatherCode:      intAt(d.WeatherCode, i),
+			PrecipitationSum: floatAt(d.PrecipitationSum, i),
+			windSpeedUnit:    d.windSpeedUnit,
+		}
+	}
+	return days
+}
+
+// Hours turns the parallel hourly series into one entry per hour, leaving values of shorter series nil
+func (h HourlyData) Hours() []*HourlyForecast {
+	hours := make([]*HourlyForecast, len(h.Time))
+	for i, at := range h.Time {
+		hours[i] = &HourlyForecast{
+			Time:        at,
+			Temperature: floatAt(h.Temperature2m, i),
+			CloudCover:  intAt(h.CloudCover, i),
+			WindSpeed:   floatAt(h.WindSpeed80m, i),
+			UvIndex:     floatAt(h.UvIndex, i),
+		}
+	}
+	return hours
+}
+
+// floatAt returns the value at an index of a series, or nil when the series is shorter or the value is missing
+func floatAt(values []float64, i int) *float64 {
+	if i >= len(values) || math.IsNaN(values[i]) {
+		return nil
+	}
+	return &values[i]
+}
+
+// intAt returns the value at an index of a series, or nil when the series is shorter or the value is missing
+func intAt(values []*int, i int) *int {
+	if i >= len(values) {
+		return nil
+	}
+	return values[i]
+}
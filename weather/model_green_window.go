@@ -0,0 +1,23 @@
+package weather
+
+// LoadProfile describes the consumption of a household around a flexible load
+type LoadProfile struct {
+	// FlexibleLoadKw is the power drawn by the load to schedule, such as a heat pump or an EV charger
+	FlexibleLoadKw float64 `json:"flexible_load_kw"`
+	// BaseLoadKw is the constant consumption of the rest of the household, used when HourlyBaseLoadKw is empty
+	BaseLoadKw float64 `json:"base_load_kw"`
+	// HourlyBaseLoadKw is the consumption of the rest of the household for each local hour of the day, from midnight
+	HourlyBaseLoadKw []float64 `json:"hourly_base_load_kw"`
+}
+
+// GreenWindow represents a time window in which a flexible load runs on local renewable surplus
+type GreenWindow struct {
+	Rank  int    `json:"rank"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// RenewableKwh is the part of the flexible load covered by renewable production left after the base load
+	RenewableKwh float64 `json:"renewable_kwh"`
+	LoadKwh      float64 `json:"load_kwh"`
+	// Coverage is the share of the flexible load covered by renewable surplus, between 0 and 1
+	Coverage float64 `json:"coverage"`
+}
@@ -0,0 +1,54 @@
+package weather
+
+import "time"
+
+// COPPoint is the coefficient of performance of a heat pump at a given outdoor temperature
+type COPPoint struct {
+	OutdoorTemperature float64 `json:"outdoor_temperature"`
+	COP                float64 `json:"cop"`
+}
+
+// Building describes the thermal behaviour of a building and its heat pump
+type Building struct {
+	// HeatLossCoefficient is the heat lost per degree of indoor-outdoor difference, in W/K
+	HeatLossCoefficient float64 `json:"heat_loss_coefficient"`
+	// Setpoint is the indoor temperature to maintain, in °C
+	Setpoint float64 `json:"setpoint"`
+	// FlowTemperature is the heating water temperature, used by the default Carnot-based COP curve
+	FlowTemperature float64 `json:"flow_temperature"`
+	// COPCurve are tabulated points of the heat pump COP; a Carnot-based curve is used without them
+	COPCurve []COPPoint `json:"cop_curve"`
+}
+
+// HeatDemandHour represents the heating need of a building over an hour
+type HeatDemandHour struct {
+	Time               string  `json:"time"`
+	OutdoorTemperature float64 `json:"outdoor_temperature"`
+	HeatKwh            float64 `json:"heat_kwh"`
+	COP                float64 `json:"cop"`
+	ElectricityKwh     float64 `json:"electricity_kwh"`
+}
+
+// HeatDemandDay represents the heating need of a building over a day
+type HeatDemandDay struct {
+	Date           string  `json:"date"`
+	HeatKwh        float64 `json:"heat_kwh"`
+	ElectricityKwh float64 `json:"electricity_kwh"`
+	// COP is the seasonal performance over the day, heat delivered divided by electricity used
+	COP        float64 `json:"cop"`
+	PeakLoadKw float64 `json:"peak_load_kw"`
+}
+
+// HeatDemandForecast represents the heating need of a building returned from the heatDemandForecast query
+type HeatDemandForecast struct {
+	ID                  string            `json:"id"`
+	LocationName        string            `json:"location_name"`
+	Latitude            string            `json:"latitude"`
+	Longitude           string            `json:"longitude"`
+	Building            Building          `json:"building"`
+	Hourly              []*HeatDemandHour `json:"hourly"`
+	Daily               []*HeatDemandDay  `json:"daily"`
+	TotalHeatKwh        float64           `json:"total_heat_kwh"`
+	TotalElectricityKwh float64           `json:"total_electricity_kwh"`
+	FetchedAt           time.Time         `json:"fetched_at"`
+}
@@ -0,0 +1,44 @@
+package weather
+
+import "time"
+
+// SolarSystem describes a photovoltaic installation
+type SolarSystem struct {
+	// CapacityKw is the peak power of the panels under standard test conditions
+	CapacityKw float64 `json:"capacity_kw"`
+	// Tilt is the angle of the panels from the horizontal, in degrees
+	Tilt float64 `json:"tilt"`
+	// Azimuth is the compass direction the panels face, in degrees (0 north, 90 east, 180 south, 270 west)
+	Azimuth float64 `json:"azimuth"`
+	// Losses are the system losses (inverter, wiring, soiling...) in percent
+	Losses float64 `json:"losses"`
+}
+
+// SolarHour represents the expected output of a photovoltaic system over an hour
+type SolarHour struct {
+	Time            string  `json:"time"`
+	Irradiance      float64 `json:"irradiance"`
+	CellTemperature float64 `json:"cell_temperature"`
+	EnergyKwh       float64 `json:"energy_kwh"`
+}
+
+// SolarDay represents the expected output of a photovoltaic system over a day
+type SolarDay struct {
+	Date      string  `json:"date"`
+	EnergyKwh float64 `json:"energy_kwh"`
+	PeakKw    float64 `json:"peak_kw"`
+}
+
+// SolarForecast represents the expected output of a photovoltaic system returned from the solarForecast query
+type SolarForecast struct {
+	ID           string       `json:"id"`
+	LocationName string       `json:"location_name"`
+	Latitude     string       `json:"latitude"`
+	Longitude    string       `json:"longitude"`
+	Timezone     string       `json:"timezone"`
+	System       SolarSystem  `json:"system"`
+	Hourly       []*SolarHour `json:"hourly"`
+	Daily        []*SolarDay  `json:"daily"`
+	TotalKwh     float64      `json:"total_kwh"`
+	FetchedAt    time.Time    `json:"fetched_at"`
+}
@@ -0,0 +1,14 @@
+package weather
+
+import "time"
+
+// ForecastAccuracy represents how far the forecasts of a metric were off at a given lead time for a location
+type ForecastAccuracy struct {
+	LocationID string    `json:"location_id"`
+	Metric     string    `json:"metric"`
+	LeadDays   int       `json:"lead_days"`
+	Samples    int       `json:"samples"`
+	MAE        float64   `json:"mae"`
+	Bias       float64   `json:"bias"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
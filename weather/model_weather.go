@@ -1,5 +1,13 @@
 package weather
 
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
 // Units represents units used in the current,hourly or daily response.
 type Units struct {
 	Time          		string `json:"time"`
@@ -10,29 +18,198 @@ type Units struct {
 	WindSpeed80m  		string `json:"wind_speed_80m"`
 	WindSpeed10mMax  	string `json:"wind_speed_10m_max"`
 	UvIndex       		string `json:"uv_index"`
+	PrecipitationSum 	string `json:"precipitation_sum"`
+}
+
+// Unit returns the unit of a metric by its Open-Meteo name
+func (u Units) Unit(metric string) string {
+	switch metric {
+	case "temperature_2m":
+		return u.Temperature2m
+	case "temperature_2m_max":
+		return u.Temperature2mMax
+	case "temperature_2m_min":
+		return u.Temperature2mMin
+	case "cloud_cover":
+		return u.CloudCover
+	case "wind_speed_80m":
+		return u.WindSpeed80m
+	case "wind_speed_10m_max":
+		return u.WindSpeed10mMax
+	case "uv_index":
+		return u.UvIndex
+	case "precipitation_sum":
+		return u.PrecipitationSum
+	}
+	return ""
 }
 
-// HourlyData represents the data in the hourly forecast.
+// HourlyData represents the data in the hourly forecast. Missing integer values are nil, and missing floats are NaN.
 type HourlyData struct {
 	Time          []string  `json:"time"`
 	Temperature2m []float64 `json:"temperature_2m"`
-	CloudCover    []int     `json:"cloud_cover"`
+	CloudCover    []*int    `json:"cloud_cover"`
 	WindSpeed80m  []float64 `json:"wind_speed_80m"`
 	UvIndex       []float64 `json:"uv_index"`
 }
 
-// DailyData represents the data in the daily forecast.
+// Series returns the values of an hourly metric by its Open-Meteo name
+func (h HourlyData) Series(metric string) ([]float64, bool) {
+	switch metric {
+	case "temperature_2m":
+		return h.Temperature2m, true
+	case "cloud_cover":
+		return intsToFloats(h.CloudCover), true
+	case "wind_speed_80m":
+		return h.WindSpeed80m, true
+	case "uv_index":
+		return h.UvIndex, true
+	}
+	return nil, false
+}
+
+// UnmarshalJSON decodes hourly data, null float values becoming NaN
+func (h *HourlyData) UnmarshalJSON(data []byte) error {
+	return unmarshalColumns(data, h)
+}
+
+// MarshalJSON encodes hourly data, NaN float values becoming null
+func (h HourlyData) MarshalJSON() ([]byte, error) {
+	return marshalColumns(&h)
+}
+
+// DailyData represents the data in the daily forecast. Missing integer values are nil, and missing floats are NaN.
 type DailyData struct {
 	Time          		[]string   	`json:"time"`
 	Temperature2mMax 	[]float64 	`json:"temperature_2m_max"`
 	Temperature2mMin 	[]float64 	`json:"temperature_2m_min"`
 	WindSpeed10mMax  	[]float64 	`json:"wind_speed_10m_max"`
-	WeatherCode     	[]int   	`json:"weather_code"`
-	WindDirectionAngle  []int   	`json:"wind_direction_10m_dominant"`
+	WeatherCode     	[]*int   	`json:"weather_code"`
+	WindDirectionAngle  []*int   	`json:"wind_direction_10m_dominant"`
 	UvIndexMax      	[]float64 	`json:"uv_index_max"`
+	PrecipitationSum 	[]float64 	`json:"precipitation_sum"`
+
+	// windSpeedUnit is the unit label of WindSpeed10mMax once converted, the km/h default when empty
+	windSpeedUnit string
 
 }
 
+// Series returns the values of a daily metric by its Open-Meteo name
+func (d DailyData) Series(metric string) ([]float64, bool) {
+	switch metric {
+	case "temperature_2m_max":
+		return d.Temperature2mMax, true
+	case "temperature_2m_min":
+		return d.Temperature2mMin, true
+	case "wind_speed_10m_max":
+		return d.WindSpeed10mMax, true
+	case "uv_index_max":
+		return d.UvIndexMax, true
+	case "precipitation_sum":
+		return d.PrecipitationSum, true
+	case "weather_code":
+		return intsToFloats(d.WeatherCode), true
+	case "wind_direction_10m_dominant":
+		return intsToFloats(d.WindDirectionAngle), true
+	}
+	return nil, false
+}
+
+// UnmarshalJSON decodes daily data, null float values becoming NaN
+func (d *DailyData) UnmarshalJSON(data []byte) error {
+	return unmarshalColumns(data, d)
+}
+
+// MarshalJSON encodes daily data, NaN float values becoming null
+func (d DailyData) MarshalJSON() ([]byte, error) {
+	return marshalColumns(&d)
+}
+
+// unmarshalColumns decodes a JSON object into the fields of a struct of series by their json name, so that a
+// missing forecast value is NaN like a missing observed one rather than 0
+func unmarshalColumns(data []byte, columns interface{}) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for name, column := range columnFields(columns) {
+		value, ok := raw[name]
+		if !ok {
+			continue
+		}
+		if _, ok := column.Interface().([]float64); !ok {
+			if err := json.Unmarshal(value, column.Addr().Interface()); err != nil {
+				return err
+			}
+			continue
+		}
+		var values []*float64
+		if err := json.Unmarshal(value, &values); err != nil {
+			return err
+		}
+		if values == nil {
+			continue
+		}
+		floats := make([]float64, len(values))
+		for i, value := range values {
+			floats[i] = math.NaN()
+			if value != nil {
+				floats[i] = *value
+			}
+		}
+		column.Set(reflect.ValueOf(floats))
+	}
+	return nil
+}
+
+// marshalColumns encodes the fields of a struct of series by their json name, NaN values becoming null as JSON has
+// no NaN
+func marshalColumns(columns interface{}) ([]byte, error) {
+	encoded := map[string]interface{}{}
+	for name, column := range columnFields(columns) {
+		floats, ok := column.Interface().([]float64)
+		if !ok || floats == nil {
+			encoded[name] = column.Interface()
+			continue
+		}
+		values := make([]*float64, len(floats))
+		for i := range floats {
+			if !math.IsNaN(floats[i]) {
+				values[i] = &floats[i]
+			}
+		}
+		encoded[name] = values
+	}
+	return json.Marshal(encoded)
+}
+
+// columnFields maps the json names of the exported fields of a struct pointer to the fields
+func columnFields(columns interface{}) map[string]reflect.Value {
+	value := reflect.ValueOf(columns).Elem()
+	fields := map[string]reflect.Value{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.IsExported() && name != "" && name != "-" {
+			fields[name] = value.Field(i)
+		}
+	}
+	return fields
+}
+
+// intsToFloats converts integer values to floats, missing values becoming NaN
+func intsToFloats(values []*int) []float64 {
+	floats := make([]float64, len(values))
+	for i, value := range values {
+		if value == nil {
+			floats[i] = math.NaN()
+			continue
+		}
+		floats[i] = float64(*value)
+	}
+	return floats
+}
+
 // CurrentData represents the current weather info.
 type CurrentData struct {
 	Temperature2m 		float64 `json:"temperature_2m"`
@@ -62,6 +239,7 @@ type WeatherResponse struct {
 
 // WeatherForecastInfo represents the  weather forecast data returned from the weatherForecast query
 type WeatherForecastInfo struct {
+	ID              string      `json:"id"`
     LocationName    string  	`json:"location_name"`
     Latitude        string  	`json:"latitude"`
     Longitude       string  	`json:"longitude"`
@@ -69,6 +247,9 @@ type WeatherForecastInfo struct {
 	Hourly          HourlyData  `json:"hourly"`
 	DailyUnits		Units  		`json:"daily_units"`
 	Daily           DailyData   `json:"daily"`
+	FetchedAt       time.Time   `json:"fetched_at"`
+	// UtcOffsetSeconds is the offset of the local times of the forecast from UTC
+	UtcOffsetSeconds int        `json:"utc_offset_seconds"`
 }
 
 // CurrentWeatherInfo represents the current weather data returned from the weatherForLocations query
@@ -87,4 +268,72 @@ type CurrentWeatherInfo struct {
 	WeatherCode     	int   	`json:"weather_code"`
 	WindDirectionAngle 	int 	`json:"wind_direction_10m"`
 	Units 				Units   `json:"units"`
+	FetchedAt 			time.Time `json:"fetched_at"`
+}
+
+// WeatherInfo is the current weather or the forecast of a location, as served by the WeatherInfo type of the
+// deprecated queries and of weatherUpdates. Only one of Current and Forecast is set.
+type WeatherInfo struct {
+	ID           string
+	LocationName string
+	Latitude     string
+	Longitude    string
+	FetchedAt    time.Time
+	Current      *CurrentWeatherInfo
+	Forecast     *WeatherForecastInfo
+}
+
+// currentWeatherInfo serves current weather as a WeatherInfo
+func currentWeatherInfo(info *CurrentWeatherInfo) *WeatherInfo {
+	return &WeatherInfo{
+		ID:           info.ID,
+		LocationName: info.LocationName,
+		Latitude:     info.Latitude,
+		Longitude:    info.Longitude,
+		FetchedAt:    info.FetchedAt,
+		Current:      info,
+	}
+}
+
+// forecastWeatherInfo serves a forecast as a WeatherInfo
+func forecastWeatherInfo(info *WeatherForecastInfo) *WeatherInfo {
+	return &WeatherInfo{
+		ID:           info.ID,
+		LocationName: info.LocationName,
+		Latitude:     info.Latitude,
+		Longitude:    info.Longitude,
+		FetchedAt:    info.FetchedAt,
+		Forecast:     info,
+	}
+}
+
+// FirstDays returns a copy of the forecast limited to its first days, hourly values included
+func (info *WeatherForecastInfo) FirstDays(days int) *WeatherForecastInfo {
+	limited := *info
+
+	limited.Daily.Time = head(info.Daily.Time, days)
+	limited.Daily.Temperature2mMax = head(info.Daily.Temperature2mMax, days)
+	limited.Daily.Temperature2mMin = head(info.Daily.Temperature2mMin, days)
+	limited.Daily.WindSpeed10mMax = head(info.Daily.WindSpeed10mMax, days)
+	limited.Daily.WeatherCode = head(info.Daily.WeatherCode, days)
+	limited.Daily.WindDirectionAngle = head(info.Daily.WindDirectionAngle, days)
+	limited.Daily.UvIndexMax = head(info.Daily.UvIndexMax, days)
+	limited.Daily.PrecipitationSum = head(info.Daily.PrecipitationSum, days)
+
+	hours := days * 24
+	limited.Hourly.Time = head(info.Hourly.Time, hours)
+	limited.Hourly.Temperature2m = head(info.Hourly.Temperature2m, hours)
+	limited.Hourly.CloudCover = head(info.Hourly.CloudCover, hours)
+	limited.Hourly.WindSpeed80m = head(info.Hourly.WindSpeed80m, hours)
+	limited.Hourly.UvIndex = head(info.Hourly.UvIndex, hours)
+
+	return &limited
+}
+
+// head returns at most the first n values of a series
+func head[T any](values []T, n int) []T {
+	if len(values) > n {
+		return values[:n]
+	}
+	return values
 }
@@ -0,0 +1,49 @@
+package weather
+
+import "time"
+
+// PowerCurvePoint is the output of a wind turbine at a given hub-height wind speed
+type PowerCurvePoint struct {
+	Speed   float64 `json:"speed"`
+	PowerKw float64 `json:"power_kw"`
+}
+
+// WindTurbine describes a wind turbine and its power curve. Speeds are in m/s.
+type WindTurbine struct {
+	RatedPowerKw float64 `json:"rated_power_kw"`
+	HubHeight    float64 `json:"hub_height"`
+	CutIn        float64 `json:"cut_in"`
+	RatedSpeed   float64 `json:"rated_speed"`
+	CutOut       float64 `json:"cut_out"`
+	// PowerCurve are tabulated points of the power curve; a cubic curve between cut-in and rated speed is used without them
+	PowerCurve []PowerCurvePoint `json:"power_curve"`
+}
+
+// WindHour represents the expected output of a wind turbine over an hour
+type WindHour struct {
+	Time          string  `json:"time"`
+	HubWindSpeed  float64 `json:"hub_wind_speed"`
+	ShearExponent float64 `json:"shear_exponent"`
+	EnergyKwh     float64 `json:"energy_kwh"`
+}
+
+// WindDay represents the expected output of a wind turbine over a day
+type WindDay struct {
+	Date           string  `json:"date"`
+	EnergyKwh      float64 `json:"energy_kwh"`
+	CapacityFactor float64 `json:"capacity_factor"`
+}
+
+// WindPowerForecast represents the expected output of a wind turbine returned from the windPowerForecast query
+type WindPowerForecast struct {
+	ID           string      `json:"id"`
+	LocationName string      `json:"location_name"`
+	Latitude     string      `json:"latitude"`
+	Longitude    string      `json:"longitude"`
+	Timezone     string      `json:"timezone"`
+	Turbine      WindTurbine `json:"turbine"`
+	Hourly       []*WindHour `json:"hourly"`
+	Daily        []*WindDay  `json:"daily"`
+	TotalKwh     float64     `json:"total_kwh"`
+	FetchedAt    time.Time   `json:"fetched_at"`
+}
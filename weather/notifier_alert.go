@@ -0,0 +1,137 @@
+package weather
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Notifier is a sink alerts are delivered to
+type Notifier interface {
+	Notify(ctx context.Context, rule *AlertRule, alert *Alert) error
+}
+
+// WebhookNotifier posts alerts as JSON to the webhook URL of their rule
+type WebhookNotifier struct {
+	Client *http.Client
+}
+
+// Notify posts the alert and fails unless the webhook answers with a 2xx status
+func (n *WebhookNotifier) Notify(ctx context.Context, rule *AlertRule, alert *Alert) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("could not encode alert: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rule.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("could not build webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not call webhook: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+// SMTPNotifier emails alerts to the address of their rule through an SMTP server
+type SMTPNotifier struct {
+	// Addr is the host:port of the SMTP server
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// Notify sends the alert email, authenticating when a username is set
+func (n *SMTPNotifier) Notify(ctx context.Context, rule *AlertRule, alert *Alert) error {
+	var auth smtp.Auth
+	if n.Username != "" {
+		host := strings.Split(n.Addr, ":")[0]
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+	to, err := emailRecipient(rule)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(n.Addr, auth, n.From, []string{to}, emailMessage(n.From, to, alert)); err != nil {
+		return fmt.Errorf("could not send alert email: %v", err)
+	}
+	return nil
+}
+
+// OutboxNotifier is a local stand-in for SMTPNotifier that writes alert emails as .eml files to a directory
+type OutboxNotifier struct {
+	Dir  string
+	From string
+}
+
+// Notify writes the alert email to the outbox directory
+func (n *OutboxNotifier) Notify(ctx context.Context, rule *AlertRule, alert *Alert) error {
+	if err := os.MkdirAll(n.Dir, 0755); err != nil {
+		return fmt.Errorf("could not create outbox: %v", err)
+	}
+	to, err := emailRecipient(rule)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(n.Dir, alert.ID+".eml")
+	if err := os.WriteFile(path, emailMessage(n.From, to, alert), 0644); err != nil {
+		return fmt.Errorf("could not write alert email: %v", err)
+	}
+	return nil
+}
+
+// FeedNotifier delivers alerts to the in-app feed. Every alert is stored in the alert history, so delivering
+// only records it as part of the feed, which clients read with the alerts query.
+type FeedNotifier struct {
+}
+
+// Notify always succeeds
+func (n *FeedNotifier) Notify(ctx context.Context, rule *AlertRule, alert *Alert) error {
+	return nil
+}
+
+// emailRecipient returns the bare address alerts of a rule are emailed to
+func emailRecipient(rule *AlertRule) (string, error) {
+	address, err := mail.ParseAddress(rule.Email)
+	if err != nil {
+		return "", fmt.Errorf("invalid alert email address: %v", err)
+	}
+	return address.Address, nil
+}
+
+// emailMessage formats an alert as a plain text email. The subject is Q-encoded whenever it holds characters
+// other than printable ASCII, so rule and location names can't add headers
+func emailMessage(from, to string, alert *Alert) []byte {
+	subject := fmt.Sprintf("[GreenHeat] %s in %s", alert.RuleName, alert.LocationName)
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", from)
+	fmt.Fprintf(&message, "To: %s\r\n", to)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", alert.TriggeredAt.Format(time.RFC1123Z))
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	message.WriteString(alert.Message + "\r\n")
+	return []byte(message.String())
+}
@@ -0,0 +1,133 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Archive resolutions supported by the historicalWeather query
+const (
+	ResolutionDaily  = "daily"
+	ResolutionHourly = "hourly"
+)
+
+// dateLayout is the layout of dates exchanged with Open-Meteo
+const dateLayout = "2006-01-02"
+
+// ArchiveProvider fetches observed weather for a location between two dates, both included
+type ArchiveProvider interface {
+	FetchArchive(location Location, start, end time.Time, metrics []string, resolution string) (*ArchiveSeries, error)
+}
+
+// OpenMeteoArchive is an ArchiveProvider backed by the Open-Meteo historical weather API
+type OpenMeteoArchive struct {
+	BaseURL string
+	// APIKey is sent to Open-Meteo when set, as commercial endpoints require
+	APIKey string
+}
+
+// FetchArchive fetches observed weather from Open-Meteo
+func (a *OpenMeteoArchive) FetchArchive(location Location, start, end time.Time, metrics []string, resolution string) (*ArchiveSeries, error) {
+	query := fmt.Sprintf("%s?latitude=%s&longitude=%s&start_date=%s&end_date=%s&%s=%s&timezone=auto&format=json",
+		a.BaseURL,
+		location.Latitude,
+		location.Longitude,
+		start.Format(dateLayout),
+		end.Format(dateLayout),
+		resolution,
+		strings.Join(metrics, ","),
+	) + apiKeyParam(a.APIKey)
+
+	resp, err := ArchiveUpstream.Get(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch archive data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return parseArchiveResponse(body, start, end, metrics, resolution)
+}
+
+// FixtureArchive is an ArchiveProvider serving canned Open-Meteo archive responses from a directory,
+// one <locationID>.json file per location, so the archive can be used without network access
+type FixtureArchive struct {
+	Dir string
+}
+
+// FetchArchive reads observed weather from the fixture of the location
+func (a *FixtureArchive) FetchArchive(location Location, start, end time.Time, metrics []string, resolution string) (*ArchiveSeries, error) {
+	body, err := os.ReadFile(filepath.Join(a.Dir, location.ID+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("no archive fixture for location %s: %v", location.ID, err)
+	}
+
+	return parseArchiveResponse(body, start, end, metrics, resolution)
+}
+
+// parseArchiveResponse extracts the requested metrics between two dates from an Open-Meteo archive payload
+func parseArchiveResponse(body []byte, start, end time.Time, metrics []string, resolution string) (*ArchiveSeries, error) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse archive data: %w", err)
+	}
+
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(payload[resolution], &data); err != nil || data == nil {
+		return nil, fmt.Errorf("archive data has no %s values", resolution)
+	}
+
+	var units map[string]string
+	if raw, ok := payload[resolution+"_units"]; ok {
+		if err := json.Unmarshal(raw, &units); err != nil {
+			return nil, fmt.Errorf("failed to parse archive units: %w", err)
+		}
+	}
+
+	var times []string
+	if err := json.Unmarshal(data["time"], &times); err != nil {
+		return nil, fmt.Errorf("failed to parse archive time steps: %w", err)
+	}
+
+	series := &ArchiveSeries{Values: map[string][]*float64{}, Units: map[string]string{"time": units["time"]}}
+	values := map[string][]*float64{}
+	for _, metric := range metrics {
+		var metricValues []*float64
+		if raw, ok := data[metric]; ok {
+			if err := json.Unmarshal(raw, &metricValues); err != nil {
+				return nil, fmt.Errorf("failed to parse archive metric %s: %w", metric, err)
+			}
+		}
+		values[metric] = metricValues
+		series.Units[metric] = units[metric]
+	}
+
+	first, last := start.Format(dateLayout), end.Format(dateLayout)
+	for i, step := range times {
+		if len(step) < len(dateLayout) || step[:len(dateLayout)] < first || step[:len(dateLayout)] > last {
+			continue
+		}
+		series.Time = append(series.Time, step)
+		for _, metric := range metrics {
+			var value *float64
+			if i < len(values[metric]) {
+				value = values[metric][i]
+			}
+			series.Values[metric] = append(series.Values[metric], value)
+		}
+	}
+
+	return series, nil
+}
@@ -0,0 +1,182 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Scheduler periodically refreshes and stores the current weather and forecast of every tracked location
+type Scheduler struct {
+	// Interval is the time between two refreshes of every location
+	Interval time.Duration
+	// RequestSpacing is the minimum delay between two upstream requests
+	RequestSpacing time.Duration
+	// Jitter is the maximum random delay added before each upstream request to spread load
+	Jitter time.Duration
+	// RateLimitBackoff is how long to pause after upstream rejected a request for exceeding its rate limit
+	RateLimitBackoff time.Duration
+	// ForecastMetrics are the daily metrics fetched for forecast snapshots
+	ForecastMetrics []string
+	// OnCurrent is called with the current weather of each location after it has been stored
+	OnCurrent func(info *CurrentWeatherInfo)
+	// OnForecast is called with the forecast of each location after it has been stored
+	OnForecast func(info *WeatherForecastInfo)
+
+	db Database
+	lc LocationController
+	wc WeatherController
+
+	mu          sync.Mutex
+	lastRun     time.Time
+	lastSuccess time.Time
+	lastError   error
+}
+
+// NewScheduler creates a Scheduler refreshing every location once per interval
+func NewScheduler(db Database, lc LocationController, wc WeatherController, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		Interval:         interval,
+		RequestSpacing:   time.Second,
+		Jitter:           2 * time.Second,
+		RateLimitBackoff: time.Minute,
+		ForecastMetrics:  DefaultForecastMetrics,
+		db:               db,
+		lc:               lc,
+		wc:               wc,
+	}
+}
+
+// Run refreshes every location once per interval until ctx is done
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RefreshAll(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Error refreshing weather: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshAll fetches and stores the current weather of all locations in one upstream request,
+// then the forecast of each location, respecting the request spacing
+func (s *Scheduler) RefreshAll(ctx context.Context) error {
+	s.mu.Lock()
+	s.lastRun = time.Now()
+	s.mu.Unlock()
+
+	err := s.refreshAll(ctx)
+
+	s.mu.Lock()
+	s.lastError = err
+	if err == nil {
+		s.lastSuccess = time.Now()
+	}
+	s.mu.Unlock()
+
+	return err
+}
+
+func (s *Scheduler) refreshAll(ctx context.Context) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+
+	current, err := s.wc.FetchWeatherForLocations(s.db, s.lc)
+	if err != nil {
+		return s.handleError(ctx, err)
+	}
+	for _, info := range current {
+		if err := s.wc.SaveCurrentSnapshot(s.db, info); err != nil {
+			return err
+		}
+		if s.OnCurrent != nil {
+			s.OnCurrent(info)
+		}
+	}
+
+	locations, err := s.lc.GetLocations(s.db)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, location := range locations {
+		if err := s.wait(ctx); err != nil {
+			return err
+		}
+
+		forecast, err := s.wc.FetchWeatherForecast(s.db, location, s.ForecastMetrics)
+		if err != nil {
+			if err := s.handleError(ctx, err); ctx.Err() != nil {
+				return err
+			}
+			failed++
+			continue
+		}
+		if err := s.wc.SaveForecastSnapshot(s.db, forecast, s.ForecastMetrics); err != nil {
+			return err
+		}
+		if s.OnForecast != nil {
+			s.OnForecast(forecast)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not refresh the forecast of %d out of %d locations", failed, len(locations))
+	}
+	return nil
+}
+
+// wait sleeps for the request spacing plus a random jitter, or until ctx is done
+func (s *Scheduler) wait(ctx context.Context) error {
+	delay := s.RequestSpacing
+	if s.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(s.Jitter)))
+	}
+	return sleep(ctx, delay)
+}
+
+// handleError backs off when upstream is rate limiting us and passes the error through
+func (s *Scheduler) handleError(ctx context.Context, err error) error {
+	if errors.Is(err, ErrRateLimited) {
+		fmt.Printf("Rate limited by upstream, pausing refreshes for %s.\n", s.RateLimitBackoff)
+		upstreamBackoffs.Inc(ForecastUpstream.Name)
+		if sleepErr := sleep(ctx, s.RateLimitBackoff); sleepErr != nil {
+			return sleepErr
+		}
+	}
+	return err
+}
+
+// LastRun returns when the scheduler last started refreshing, when it last succeeded and the last error
+func (s *Scheduler) LastRun() (started time.Time, succeeded time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastRun, s.lastSuccess, s.lastError
+}
+
+// sleep waits for the given duration or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,420 @@
+package weather
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// fieldResolver resolves a field of the schema, and subscribes to it for subscription fields
+type fieldResolver struct {
+	Resolve   graphql.FieldResolveFn
+	Subscribe graphql.FieldResolveFn
+	// ReadsStruct is set for fields that only copy a field of the Go model, which are not timed
+	ReadsStruct bool
+}
+
+// scalars are the custom scalars the SDL may declare, the built-in ones are always known
+var scalars = map[string]*graphql.Scalar{
+	"DateTime": graphql.DateTime,
+}
+
+// buildSchema builds the executable schema from the SDL, resolving each field of every object with the resolver
+// registered for its "Type.field" key. The SDL must be the one schema_gen.go was generated from, and every field
+// must have exactly one resolver, so a schema edited without running go generate fails at startup rather than
+// serving nulls
+func buildSchema(sdl string, resolvers map[string]fieldResolver) (graphql.Schema, error) {
+	sum := sha256.Sum256([]byte(sdl))
+	if hex.EncodeToString(sum[:]) != schemaHash {
+		return graphql.Schema{}, fmt.Errorf("schema_gen.go is out of date with schema.graphql, run go generate ./weather")
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: sdl})
+	if err != nil {
+		return graphql.Schema{}, fmt.Errorf("could not parse schema: %v", err)
+	}
+
+	b := &schemaBuilder{
+		types:     map[string]graphql.Type{},
+		resolvers: resolvers,
+		used:      map[string]bool{},
+	}
+	for name, scalar := range scalars {
+		b.types[name] = scalar
+	}
+	for _, scalar := range []*graphql.Scalar{graphql.String, graphql.Int, graphql.Float, graphql.Boolean, graphql.ID} {
+		b.types[scalar.Name()] = scalar
+	}
+
+	var roots *ast.SchemaDefinition
+	var named []graphql.Type
+	for _, definition := range doc.Definitions {
+		var t graphql.Type
+		switch definition := definition.(type) {
+		case *ast.SchemaDefinition:
+			roots = definition
+			continue
+		case *ast.ScalarDefinition:
+			if _, ok := scalars[definition.Name.Value]; !ok {
+				return graphql.Schema{}, fmt.Errorf("unsupported scalar %s", definition.Name.Value)
+			}
+			continue
+		case *ast.EnumDefinition:
+			t = b.enum(definition)
+		case *ast.InputObjectDefinition:
+			t = b.input(definition)
+		case *ast.ObjectDefinition:
+			t = b.object(definition)
+		default:
+			return graphql.Schema{}, fmt.Errorf("unsupported definition %s", definition.GetKind())
+		}
+		b.types[t.Name()] = t
+		named = append(named, t)
+	}
+	if roots == nil {
+		return graphql.Schema{}, fmt.Errorf("schema has no schema definition")
+	}
+
+	config := graphql.SchemaConfig{Types: named}
+	for _, operation := range roots.OperationTypes {
+		root, ok := b.types[operation.Type.Name.Value].(*graphql.Object)
+		if !ok {
+			return graphql.Schema{}, fmt.Errorf("%s type %s is not an object", operation.Operation, operation.Type.Name.Value)
+		}
+		switch operation.Operation {
+		case ast.OperationTypeQuery:
+			config.Query = root
+		case ast.OperationTypeMutation:
+			config.Mutation = root
+		case ast.OperationTypeSubscription:
+			config.Subscription = root
+		}
+	}
+
+	// Field thunks run while the schema is built, so their errors are only known afterwards
+	schema, err := graphql.NewSchema(config)
+	if b.err != nil {
+		return graphql.Schema{}, b.err
+	}
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	for _, key := range sortedKeys(resolvers) {
+		if !b.used[key] {
+			return graphql.Schema{}, fmt.Errorf("resolver %s has no field in the schema", key)
+		}
+	}
+	return schema, nil
+}
+
+// mustBuildSchema builds the schema and panics when the SDL and the generated code disagree
+func mustBuildSchema(sdl string, resolvers map[string]fieldResolver) graphql.Schema {
+	schema, err := buildSchema(sdl, resolvers)
+	if err != nil {
+		panic(fmt.Sprintf("could not build the GraphQL schema: %v", err))
+	}
+	return schema
+}
+
+// schemaBuilder turns the definitions of the SDL into graphql-go types
+type schemaBuilder struct {
+	types     map[string]graphql.Type
+	resolvers map[string]fieldResolver
+	used      map[string]bool
+	// err is the first error met while resolving the fields of the types
+	err error
+}
+
+func (b *schemaBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *schemaBuilder) enum(definition *ast.EnumDefinition) *graphql.Enum {
+	values := graphql.EnumValueConfigMap{}
+	for _, value := range definition.Values {
+		// Enum values are resolved to their own name, which is what the generated enum types hold
+		values[value.Name.Value] = &graphql.EnumValueConfig{
+			Value:             value.Name.Value,
+			Description:       descriptionOf(value.Description),
+			DeprecationReason: deprecationOf(value.Directives),
+		}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name:        definition.Name.Value,
+		Description: descriptionOf(definition.Description),
+		Values:      values,
+	})
+}
+
+func (b *schemaBuilder) input(definition *ast.InputObjectDefinition) *graphql.InputObject {
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        definition.Name.Value,
+		Description: descriptionOf(definition.Description),
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			fields := graphql.InputObjectConfigFieldMap{}
+			for _, value := range definition.Fields {
+				t, defaultValue := b.inputValue(definition.Name.Value+"."+value.Name.Value, value)
+				fields[value.Name.Value] = &graphql.InputObjectFieldConfig{
+					Type:         t,
+					DefaultValue: defaultValue,
+					Description:  descriptionOf(value.Description),
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+func (b *schemaBuilder) object(definition *ast.ObjectDefinition) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        definition.Name.Value,
+		Description: descriptionOf(definition.Description),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{}
+			for _, field := range definition.Fields {
+				key := definition.Name.Value + "." + field.Name.Value
+				resolver, ok := b.resolvers[key]
+				if !ok {
+					b.fail(fmt.Errorf("field %s has no resolver", key))
+					continue
+				}
+				b.used[key] = true
+
+				args := graphql.FieldConfigArgument{}
+				for _, arg := range field.Arguments {
+					t, defaultValue := b.inputValue(key+"("+arg.Name.Value+")", arg)
+					args[arg.Name.Value] = &graphql.ArgumentConfig{
+						Type:         t,
+						DefaultValue: defaultValue,
+						Description:  descriptionOf(arg.Description),
+					}
+				}
+
+				resolve := resolver.Resolve
+				if !resolver.ReadsStruct {
+					resolve = timedResolver(key, resolve)
+				}
+				output, _ := b.typeOf(key, field.Type).(graphql.Output)
+				fields[field.Name.Value] = &graphql.Field{
+					Type:              output,
+					Args:              args,
+					Resolve:           resolve,
+					Subscribe:         resolver.Subscribe,
+					Description:       descriptionOf(field.Description),
+					DeprecationReason: deprecationOf(field.Directives),
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+// inputValue returns the type and default value of an argument or input field
+func (b *schemaBuilder) inputValue(key string, value *ast.InputValueDefinition) (graphql.Input, interface{}) {
+	t, ok := b.typeOf(key, value.Type).(graphql.Input)
+	if !ok {
+		b.fail(fmt.Errorf("%s is not an input type", key))
+		return nil, nil
+	}
+	if value.DefaultValue == nil {
+		return t, nil
+	}
+	defaultValue := literalValue(t, value.DefaultValue)
+	if defaultValue == nil {
+		b.fail(fmt.Errorf("invalid default value of %s", key))
+	}
+	return t, defaultValue
+}
+
+// typeOf returns the graphql-go type of a type reference, once the named types are all declared
+func (b *schemaBuilder) typeOf(key string, t ast.Type) graphql.Type {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return graphql.NewNonNull(b.typeOf(key, t.Type))
+	case *ast.List:
+		return graphql.NewList(b.typeOf(key, t.Type))
+	case *ast.Named:
+		if named, ok := b.types[t.Name.Value]; ok {
+			return named
+		}
+		b.fail(fmt.Errorf("%s refers to unknown type %s", key, t.Name.Value))
+	}
+	return graphql.String
+}
+
+// literalValue converts a literal of the SDL to the value graphql-go would coerce it to for the type
+func literalValue(t graphql.Input, value ast.Value) interface{} {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return literalValue(t.OfType.(graphql.Input), value)
+	case *graphql.List:
+		itemType := t.OfType.(graphql.Input)
+		list, ok := value.(*ast.ListValue)
+		if !ok {
+			return []interface{}{literalValue(itemType, value)}
+		}
+		items := make([]interface{}, len(list.Values))
+		for i, item := range list.Values {
+			items[i] = literalValue(itemType, item)
+		}
+		return items
+	case *graphql.InputObject:
+		object, ok := value.(*ast.ObjectValue)
+		if !ok {
+			return nil
+		}
+		fields := map[string]interface{}{}
+		for _, field := range object.Fields {
+			if config, ok := t.Fields()[field.Name.Value]; ok {
+				fields[field.Name.Value] = literalValue(config.Type, field.Value)
+			}
+		}
+		return fields
+	case *graphql.Enum:
+		return t.ParseLiteral(value)
+	case *graphql.Scalar:
+		return t.ParseLiteral(value)
+	}
+	return nil
+}
+
+func descriptionOf(value *ast.StringValue) string {
+	if value == nil {
+		return ""
+	}
+	return value.Value
+}
+
+// deprecationOf returns the reason of a @deprecated directive, if any
+func deprecationOf(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name.Value != "deprecated" {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if reason, ok := arg.Value.(*ast.StringValue); ok && arg.Name.Value == "reason" {
+				return reason.Value
+			}
+		}
+		return graphql.DefaultDeprecationReason
+	}
+	return ""
+}
+
+// sourceOf returns the object a field is resolved on, whether graphql-go holds it by value or by pointer
+func sourceOf[T any](source interface{}) (*T, bool) {
+	switch obj := source.(type) {
+	case *T:
+		return obj, obj != nil
+	case T:
+		return &obj, true
+	}
+	return nil, false
+}
+
+// structField resolves a field read from a Go model
+func structField[T any](get func(obj *T) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		obj, ok := sourceOf[T](p.Source)
+		if !ok {
+			return nil, nil
+		}
+		return get(obj), nil
+	}
+}
+
+// resolved returns the result of a typed resolver to graphql-go. A nil slice resolves to null, as graphql-go would
+// otherwise serve it as an empty list
+func resolved[T any](value T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice && v.IsNil() {
+		return nil, nil
+	}
+	return value, nil
+}
+
+// forward passes the events of a typed subscription to graphql-go until the subscription ends
+func forward[T any](ctx context.Context, events <-chan T) chan interface{} {
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// decodeValue reads a coerced scalar argument or input field, the zero value when it is null
+func decodeValue[T any](value interface{}) T {
+	decoded, _ := value.(T)
+	return decoded
+}
+
+// decodeValuePointer reads a coerced scalar argument or input field, nil when it is null
+func decodeValuePointer[T any](value interface{}) *T {
+	decoded, ok := value.(T)
+	if !ok {
+		return nil
+	}
+	return &decoded
+}
+
+// decodeEnum reads a coerced enum argument or input field, whose value is the name of the enum value
+func decodeEnum[E ~string](value interface{}) E {
+	name, _ := value.(string)
+	return E(name)
+}
+
+// decodeEnumPointer reads a coerced enum argument or input field, nil when it is null
+func decodeEnumPointer[E ~string](value interface{}) *E {
+	if _, ok := value.(string); !ok {
+		return nil
+	}
+	decoded := decodeEnum[E](value)
+	return &decoded
+}
+
+// decodeList reads a coerced list argument or input field, nil when it is null
+func decodeList[T any](value interface{}, decode func(value interface{}) T) []T {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	decoded := make([]T, len(items))
+	for i, item := range items {
+		decoded[i] = decode(item)
+	}
+	return decoded
+}
+
+// decodePointer reads a coerced input object argument or input field, nil when it is null
+func decodePointer[T any](value interface{}, decode func(value interface{}) T) *T {
+	if value == nil {
+		return nil
+	}
+	decoded := decode(value)
+	return &decoded
+}
@@ -0,0 +1,1994 @@
+// Code generated by schemagen from schema.graphql. DO NOT EDIT.
+
+package weather
+
+import (
+	"context"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// schemaHash is the SHA-256 of the schema.graphql this file was generated from
+const schemaHash = "a6fa0751748f0836089d46a51a1da1f160674ec1148c45a3f2c983de215f5e36"
+
+// PrecipitationUnit is the GraphQL enum PrecipitationUnit
+type PrecipitationUnit string
+
+// Values of PrecipitationUnit
+const (
+	PrecipitationUnitInch PrecipitationUnit = "INCH"
+	PrecipitationUnitMm   PrecipitationUnit = "MM"
+)
+
+// TemperatureUnit is the GraphQL enum TemperatureUnit
+type TemperatureUnit string
+
+// Values of TemperatureUnit
+const (
+	TemperatureUnitCelsius    TemperatureUnit = "CELSIUS"
+	TemperatureUnitFahrenheit TemperatureUnit = "FAHRENHEIT"
+)
+
+// UnitSystem is the GraphQL enum UnitSystem
+type UnitSystem string
+
+// Values of UnitSystem
+const (
+	// UnitSystemImperial °F, mph and inches
+	UnitSystemImperial UnitSystem = "IMPERIAL"
+	// UnitSystemMetric °C, km/h and mm
+	UnitSystemMetric UnitSystem = "METRIC"
+)
+
+// WindSpeedUnit is the GraphQL enum WindSpeedUnit
+type WindSpeedUnit string
+
+// Values of WindSpeedUnit
+const (
+	WindSpeedUnitKmh WindSpeedUnit = "KMH"
+	WindSpeedUnitKn  WindSpeedUnit = "KN"
+	WindSpeedUnitMph WindSpeedUnit = "MPH"
+	WindSpeedUnitMs  WindSpeedUnit = "MS"
+)
+
+// AlertRuleInput is the GraphQL input AlertRuleInput
+type AlertRuleInput struct {
+	// Notification channels among webhook, email and feed, feed when empty
+	Channels        []string
+	CooldownMinutes int
+	Email           *string
+	Enabled         bool
+	HorizonHours    int
+	// Locations watched by the rule, every location when empty
+	LocationIDs []string
+	// Daily or hourly forecast metric, e.g. temperature_2m_min or wind_speed_80m
+	Metric string
+	Name   string
+	// One of <, <=, >, >=, ==
+	Operator string
+	// Threshold in metric units: °C for temperatures, km/h for wind speeds, mm for precipitation, % for cloud cover
+	Threshold  float64
+	WebhookURL *string
+}
+
+// decodeAlertRuleInput converts the values graphql-go coerced, defaults included, to AlertRuleInput
+func decodeAlertRuleInput(value interface{}) AlertRuleInput {
+	fields, _ := value.(map[string]interface{})
+	return AlertRuleInput{
+		Channels:        decodeList(fields["channels"], decodeValue[string]),
+		CooldownMinutes: decodeValue[int](fields["cooldownMinutes"]),
+		Email:           decodeValuePointer[string](fields["email"]),
+		Enabled:         decodeValue[bool](fields["enabled"]),
+		HorizonHours:    decodeValue[int](fields["horizonHours"]),
+		LocationIDs:     decodeList(fields["locationIDs"], decodeValue[string]),
+		Metric:          decodeValue[string](fields["metric"]),
+		Name:            decodeValue[string](fields["name"]),
+		Operator:        decodeValue[string](fields["operator"]),
+		Threshold:       decodeValue[float64](fields["threshold"]),
+		WebhookURL:      decodeValuePointer[string](fields["webhookURL"]),
+	}
+}
+
+// BuildingInput is the GraphQL input BuildingInput
+type BuildingInput struct {
+	// Heat pump COP by outdoor temperature, a Carnot-based curve is used without it
+	CopCurve []COPPointInput
+	// Heating water temperature in °C, used for the default COP curve
+	FlowTemperature float64
+	// Heat lost per degree of indoor-outdoor difference in W/K
+	HeatLossCoefficient float64
+	// Indoor temperature to maintain in °C
+	Setpoint float64
+}
+
+// decodeBuildingInput converts the values graphql-go coerced, defaults included, to BuildingInput
+func decodeBuildingInput(value interface{}) BuildingInput {
+	fields, _ := value.(map[string]interface{})
+	return BuildingInput{
+		CopCurve:            decodeList(fields["copCurve"], decodeCOPPointInput),
+		FlowTemperature:     decodeValue[float64](fields["flowTemperature"]),
+		HeatLossCoefficient: decodeValue[float64](fields["heatLossCoefficient"]),
+		Setpoint:            decodeValue[float64](fields["setpoint"]),
+	}
+}
+
+// COPPointInput is the GraphQL input COPPointInput
+type COPPointInput struct {
+	Cop                float64
+	OutdoorTemperature float64
+}
+
+// decodeCOPPointInput converts the values graphql-go coerced, defaults included, to COPPointInput
+func decodeCOPPointInput(value interface{}) COPPointInput {
+	fields, _ := value.(map[string]interface{})
+	return COPPointInput{
+		Cop:                decodeValue[float64](fields["cop"]),
+		OutdoorTemperature: decodeValue[float64](fields["outdoorTemperature"]),
+	}
+}
+
+// LoadProfileInput is the GraphQL input LoadProfileInput
+type LoadProfileInput struct {
+	// Constant consumption of the rest of the household in kW
+	BaseLoadKw float64
+	// Power drawn by the load to schedule in kW
+	FlexibleLoadKw float64
+	// Consumption of the rest of the household for each of the 24 local hours, replacing baseLoadKw
+	HourlyBaseLoadKw []float64
+}
+
+// decodeLoadProfileInput converts the values graphql-go coerced, defaults included, to LoadProfileInput
+func decodeLoadProfileInput(value interface{}) LoadProfileInput {
+	fields, _ := value.(map[string]interface{})
+	return LoadProfileInput{
+		BaseLoadKw:       decodeValue[float64](fields["baseLoadKw"]),
+		FlexibleLoadKw:   decodeValue[float64](fields["flexibleLoadKw"]),
+		HourlyBaseLoadKw: decodeList(fields["hourlyBaseLoadKw"], decodeValue[float64]),
+	}
+}
+
+// PowerCurvePointInput is the GraphQL input PowerCurvePointInput
+type PowerCurvePointInput struct {
+	PowerKw float64
+	// Hub-height wind speed in m/s
+	Speed float64
+}
+
+// decodePowerCurvePointInput converts the values graphql-go coerced, defaults included, to PowerCurvePointInput
+func decodePowerCurvePointInput(value interface{}) PowerCurvePointInput {
+	fields, _ := value.(map[string]interface{})
+	return PowerCurvePointInput{
+		PowerKw: decodeValue[float64](fields["powerKw"]),
+		Speed:   decodeValue[float64](fields["speed"]),
+	}
+}
+
+// SolarSystemInput is the GraphQL input SolarSystemInput
+type SolarSystemInput struct {
+	// Compass direction the panels face in degrees (180 is south)
+	Azimuth float64
+	// Peak power of the panels in kW
+	CapacityKw float64
+	// System losses in percent
+	Losses float64
+	// Angle of the panels from the horizontal in degrees
+	Tilt float64
+}
+
+// decodeSolarSystemInput converts the values graphql-go coerced, defaults included, to SolarSystemInput
+func decodeSolarSystemInput(value interface{}) SolarSystemInput {
+	fields, _ := value.(map[string]interface{})
+	return SolarSystemInput{
+		Azimuth:    decodeValue[float64](fields["azimuth"]),
+		CapacityKw: decodeValue[float64](fields["capacityKw"]),
+		Losses:     decodeValue[float64](fields["losses"]),
+		Tilt:       decodeValue[float64](fields["tilt"]),
+	}
+}
+
+// WindTurbineInput is the GraphQL input WindTurbineInput
+type WindTurbineInput struct {
+	// Cut-in wind speed in m/s
+	CutIn float64
+	// Cut-out wind speed in m/s
+	CutOut float64
+	// Hub height in metres
+	HubHeight float64
+	// Tabulated power curve, a cubic curve between cut-in and rated speed is used without it
+	PowerCurve   []PowerCurvePointInput
+	RatedPowerKw float64
+	// Wind speed in m/s from which the rated power is produced
+	RatedSpeed float64
+}
+
+// decodeWindTurbineInput converts the values graphql-go coerced, defaults included, to WindTurbineInput
+func decodeWindTurbineInput(value interface{}) WindTurbineInput {
+	fields, _ := value.(map[string]interface{})
+	return WindTurbineInput{
+		CutIn:        decodeValue[float64](fields["cutIn"]),
+		CutOut:       decodeValue[float64](fields["cutOut"]),
+		HubHeight:    decodeValue[float64](fields["hubHeight"]),
+		PowerCurve:   decodeList(fields["powerCurve"], decodePowerCurvePointInput),
+		RatedPowerKw: decodeValue[float64](fields["ratedPowerKw"]),
+		RatedSpeed:   decodeValue[float64](fields["ratedSpeed"]),
+	}
+}
+
+// ForecastDegreeDaysArgs are the arguments of Forecast.degreeDays
+type ForecastDegreeDaysArgs struct {
+	// Base temperature of heating degree days
+	Base float64
+	// Base temperature of cooling degree days
+	CoolingBase float64
+	// mean (default), metoffice or integration
+	Method string
+}
+
+// decodeForecastDegreeDaysArgs converts the values graphql-go coerced, defaults included, to ForecastDegreeDaysArgs
+func decodeForecastDegreeDaysArgs(value interface{}) ForecastDegreeDaysArgs {
+	fields, _ := value.(map[string]interface{})
+	return ForecastDegreeDaysArgs{
+		Base:        decodeValue[float64](fields["base"]),
+		CoolingBase: decodeValue[float64](fields["coolingBase"]),
+		Method:      decodeValue[string](fields["method"]),
+	}
+}
+
+// HistoricalWeatherDegreeDaysArgs are the arguments of HistoricalWeather.degreeDays
+type HistoricalWeatherDegreeDaysArgs struct {
+	// Base temperature of heating degree days
+	Base float64
+	// Base temperature of cooling degree days
+	CoolingBase float64
+	// mean (default), metoffice or integration
+	Method string
+}
+
+// decodeHistoricalWeatherDegreeDaysArgs converts the values graphql-go coerced, defaults included, to HistoricalWeatherDegreeDaysArgs
+func decodeHistoricalWeatherDegreeDaysArgs(value interface{}) HistoricalWeatherDegreeDaysArgs {
+	fields, _ := value.(map[string]interface{})
+	return HistoricalWeatherDegreeDaysArgs{
+		Base:        decodeValue[float64](fields["base"]),
+		CoolingBase: decodeValue[float64](fields["coolingBase"]),
+		Method:      decodeValue[string](fields["method"]),
+	}
+}
+
+// LocationCurrentArgs are the arguments of Location.current
+type LocationCurrentArgs struct {
+	// Overrides the precipitation unit of the preset
+	PrecipitationUnit *PrecipitationUnit
+	// Overrides the temperature unit of the preset
+	TemperatureUnit *TemperatureUnit
+	// Unit system preset, METRIC by default
+	Units UnitSystem
+	// Overrides the wind speed unit of the preset
+	WindSpeedUnit *WindSpeedUnit
+}
+
+// decodeLocationCurrentArgs converts the values graphql-go coerced, defaults included, to LocationCurrentArgs
+func decodeLocationCurrentArgs(value interface{}) LocationCurrentArgs {
+	fields, _ := value.(map[string]interface{})
+	return LocationCurrentArgs{
+		PrecipitationUnit: decodeEnumPointer[PrecipitationUnit](fields["precipitationUnit"]),
+		TemperatureUnit:   decodeEnumPointer[TemperatureUnit](fields["temperatureUnit"]),
+		Units:             decodeEnum[UnitSystem](fields["units"]),
+		WindSpeedUnit:     decodeEnumPointer[WindSpeedUnit](fields["windSpeedUnit"]),
+	}
+}
+
+// LocationForecastArgs are the arguments of Location.forecast
+type LocationForecastArgs struct {
+	// Number of days from today, up to 7
+	Days int
+	// Daily and hourly Open-Meteo metrics, the stored forecast metrics when empty
+	Metrics []string
+	// Overrides the precipitation unit of the preset
+	PrecipitationUnit *PrecipitationUnit
+	// Overrides the temperature unit of the preset
+	TemperatureUnit *TemperatureUnit
+	// Unit system preset, METRIC by default
+	Units UnitSystem
+	// Overrides the wind speed unit of the preset
+	WindSpeedUnit *WindSpeedUnit
+}
+
+// decodeLocationForecastArgs converts the values graphql-go coerced, defaults included, to LocationForecastArgs
+func decodeLocationForecastArgs(value interface{}) LocationForecastArgs {
+	fields, _ := value.(map[string]interface{})
+	return LocationForecastArgs{
+		Days:              decodeValue[int](fields["days"]),
+		Metrics:           decodeList(fields["metrics"], decodeValue[string]),
+		PrecipitationUnit: decodeEnumPointer[PrecipitationUnit](fields["precipitationUnit"]),
+		TemperatureUnit:   decodeEnumPointer[TemperatureUnit](fields["temperatureUnit"]),
+		Units:             decodeEnum[UnitSystem](fields["units"]),
+		WindSpeedUnit:     decodeEnumPointer[WindSpeedUnit](fields["windSpeedUnit"]),
+	}
+}
+
+// RootMutationAcknowledgeAlertArgs are the arguments of RootMutation.acknowledgeAlert
+type RootMutationAcknowledgeAlertArgs struct {
+	ID string
+}
+
+// decodeRootMutationAcknowledgeAlertArgs converts the values graphql-go coerced, defaults included, to RootMutationAcknowledgeAlertArgs
+func decodeRootMutationAcknowledgeAlertArgs(value interface{}) RootMutationAcknowledgeAlertArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationAcknowledgeAlertArgs{
+		ID: decodeValue[string](fields["id"]),
+	}
+}
+
+// RootMutationAddLocationArgs are the arguments of RootMutation.addLocation
+type RootMutationAddLocationArgs struct {
+	Latitude  string
+	Longitude string
+	Name      *string
+}
+
+// decodeRootMutationAddLocationArgs converts the values graphql-go coerced, defaults included, to RootMutationAddLocationArgs
+func decodeRootMutationAddLocationArgs(value interface{}) RootMutationAddLocationArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationAddLocationArgs{
+		Latitude:  decodeValue[string](fields["latitude"]),
+		Longitude: decodeValue[string](fields["longitude"]),
+		Name:      decodeValuePointer[string](fields["name"]),
+	}
+}
+
+// RootMutationCreateAlertRuleArgs are the arguments of RootMutation.createAlertRule
+type RootMutationCreateAlertRuleArgs struct {
+	Input AlertRuleInput
+}
+
+// decodeRootMutationCreateAlertRuleArgs converts the values graphql-go coerced, defaults included, to RootMutationCreateAlertRuleArgs
+func decodeRootMutationCreateAlertRuleArgs(value interface{}) RootMutationCreateAlertRuleArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationCreateAlertRuleArgs{
+		Input: decodeAlertRuleInput(fields["input"]),
+	}
+}
+
+// RootMutationCreateWebhookArgs are the arguments of RootMutation.createWebhook
+type RootMutationCreateWebhookArgs struct {
+	// Among location.created, location.updated, location.deleted and forecast.refreshed, all of them when empty
+	Events []string
+	// Key of the payload signatures, generated when empty
+	Secret *string
+	URL    string
+}
+
+// decodeRootMutationCreateWebhookArgs converts the values graphql-go coerced, defaults included, to RootMutationCreateWebhookArgs
+func decodeRootMutationCreateWebhookArgs(value interface{}) RootMutationCreateWebhookArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationCreateWebhookArgs{
+		Events: decodeList(fields["events"], decodeValue[string]),
+		Secret: decodeValuePointer[string](fields["secret"]),
+		URL:    decodeValue[string](fields["url"]),
+	}
+}
+
+// RootMutationDeleteAlertRuleArgs are the arguments of RootMutation.deleteAlertRule
+type RootMutationDeleteAlertRuleArgs struct {
+	ID string
+}
+
+// decodeRootMutationDeleteAlertRuleArgs converts the values graphql-go coerced, defaults included, to RootMutationDeleteAlertRuleArgs
+func decodeRootMutationDeleteAlertRuleArgs(value interface{}) RootMutationDeleteAlertRuleArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationDeleteAlertRuleArgs{
+		ID: decodeValue[string](fields["id"]),
+	}
+}
+
+// RootMutationDeleteLocationArgs are the arguments of RootMutation.deleteLocation
+type RootMutationDeleteLocationArgs struct {
+	ID string
+}
+
+// decodeRootMutationDeleteLocationArgs converts the values graphql-go coerced, defaults included, to RootMutationDeleteLocationArgs
+func decodeRootMutationDeleteLocationArgs(value interface{}) RootMutationDeleteLocationArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationDeleteLocationArgs{
+		ID: decodeValue[string](fields["id"]),
+	}
+}
+
+// RootMutationDeleteWebhookArgs are the arguments of RootMutation.deleteWebhook
+type RootMutationDeleteWebhookArgs struct {
+	ID string
+}
+
+// decodeRootMutationDeleteWebhookArgs converts the values graphql-go coerced, defaults included, to RootMutationDeleteWebhookArgs
+func decodeRootMutationDeleteWebhookArgs(value interface{}) RootMutationDeleteWebhookArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationDeleteWebhookArgs{
+		ID: decodeValue[string](fields["id"]),
+	}
+}
+
+// RootMutationSetWebhookEnabledArgs are the arguments of RootMutation.setWebhookEnabled
+type RootMutationSetWebhookEnabledArgs struct {
+	Enabled bool
+	ID      string
+}
+
+// decodeRootMutationSetWebhookEnabledArgs converts the values graphql-go coerced, defaults included, to RootMutationSetWebhookEnabledArgs
+func decodeRootMutationSetWebhookEnabledArgs(value interface{}) RootMutationSetWebhookEnabledArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationSetWebhookEnabledArgs{
+		Enabled: decodeValue[bool](fields["enabled"]),
+		ID:      decodeValue[string](fields["id"]),
+	}
+}
+
+// RootMutationTestWebhookArgs are the arguments of RootMutation.testWebhook
+type RootMutationTestWebhookArgs struct {
+	ID string
+}
+
+// decodeRootMutationTestWebhookArgs converts the values graphql-go coerced, defaults included, to RootMutationTestWebhookArgs
+func decodeRootMutationTestWebhookArgs(value interface{}) RootMutationTestWebhookArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationTestWebhookArgs{
+		ID: decodeValue[string](fields["id"]),
+	}
+}
+
+// RootMutationUpdateAlertRuleArgs are the arguments of RootMutation.updateAlertRule
+type RootMutationUpdateAlertRuleArgs struct {
+	ID    string
+	Input AlertRuleInput
+}
+
+// decodeRootMutationUpdateAlertRuleArgs converts the values graphql-go coerced, defaults included, to RootMutationUpdateAlertRuleArgs
+func decodeRootMutationUpdateAlertRuleArgs(value interface{}) RootMutationUpdateAlertRuleArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationUpdateAlertRuleArgs{
+		ID:    decodeValue[string](fields["id"]),
+		Input: decodeAlertRuleInput(fields["input"]),
+	}
+}
+
+// RootMutationUpdateLocationArgs are the arguments of RootMutation.updateLocation
+type RootMutationUpdateLocationArgs struct {
+	ID   string
+	Name string
+}
+
+// decodeRootMutationUpdateLocationArgs converts the values graphql-go coerced, defaults included, to RootMutationUpdateLocationArgs
+func decodeRootMutationUpdateLocationArgs(value interface{}) RootMutationUpdateLocationArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootMutationUpdateLocationArgs{
+		ID:   decodeValue[string](fields["id"]),
+		Name: decodeValue[string](fields["name"]),
+	}
+}
+
+// RootQueryWeatherForecastArgs are the arguments of RootQuery.WeatherForecast
+type RootQueryWeatherForecastArgs struct {
+	LocationID *string
+	Metrics    []string
+	// Overrides the precipitation unit of the preset
+	PrecipitationUnit *PrecipitationUnit
+	// Overrides the temperature unit of the preset
+	TemperatureUnit *TemperatureUnit
+	// Unit system preset, METRIC by default
+	Units UnitSystem
+	// Overrides the wind speed unit of the preset
+	WindSpeedUnit *WindSpeedUnit
+}
+
+// decodeRootQueryWeatherForecastArgs converts the values graphql-go coerced, defaults included, to RootQueryWeatherForecastArgs
+func decodeRootQueryWeatherForecastArgs(value interface{}) RootQueryWeatherForecastArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryWeatherForecastArgs{
+		LocationID:        decodeValuePointer[string](fields["locationID"]),
+		Metrics:           decodeList(fields["metrics"], decodeValue[string]),
+		PrecipitationUnit: decodeEnumPointer[PrecipitationUnit](fields["precipitationUnit"]),
+		TemperatureUnit:   decodeEnumPointer[TemperatureUnit](fields["temperatureUnit"]),
+		Units:             decodeEnum[UnitSystem](fields["units"]),
+		WindSpeedUnit:     decodeEnumPointer[WindSpeedUnit](fields["windSpeedUnit"]),
+	}
+}
+
+// RootQueryAlertsArgs are the arguments of RootQuery.alerts
+type RootQueryAlertsArgs struct {
+	Limit          int
+	LocationID     *string
+	RuleID         *string
+	Since          *time.Time
+	Unacknowledged bool
+}
+
+// decodeRootQueryAlertsArgs converts the values graphql-go coerced, defaults included, to RootQueryAlertsArgs
+func decodeRootQueryAlertsArgs(value interface{}) RootQueryAlertsArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryAlertsArgs{
+		Limit:          decodeValue[int](fields["limit"]),
+		LocationID:     decodeValuePointer[string](fields["locationID"]),
+		RuleID:         decodeValuePointer[string](fields["ruleID"]),
+		Since:          decodeValuePointer[time.Time](fields["since"]),
+		Unacknowledged: decodeValue[bool](fields["unacknowledged"]),
+	}
+}
+
+// RootQueryForecastAccuracyArgs are the arguments of RootQuery.forecastAccuracy
+type RootQueryForecastAccuracyArgs struct {
+	LeadDays   *int
+	LocationID *string
+	Metric     *string
+}
+
+// decodeRootQueryForecastAccuracyArgs converts the values graphql-go coerced, defaults included, to RootQueryForecastAccuracyArgs
+func decodeRootQueryForecastAccuracyArgs(value interface{}) RootQueryForecastAccuracyArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryForecastAccuracyArgs{
+		LeadDays:   decodeValuePointer[int](fields["leadDays"]),
+		LocationID: decodeValuePointer[string](fields["locationID"]),
+		Metric:     decodeValuePointer[string](fields["metric"]),
+	}
+}
+
+// RootQueryForecastHistoryArgs are the arguments of RootQuery.forecastHistory
+type RootQueryForecastHistoryArgs struct {
+	IssuedAfter  *time.Time
+	IssuedBefore *time.Time
+	LocationID   string
+}
+
+// decodeRootQueryForecastHistoryArgs converts the values graphql-go coerced, defaults included, to RootQueryForecastHistoryArgs
+func decodeRootQueryForecastHistoryArgs(value interface{}) RootQueryForecastHistoryArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryForecastHistoryArgs{
+		IssuedAfter:  decodeValuePointer[time.Time](fields["issuedAfter"]),
+		IssuedBefore: decodeValuePointer[time.Time](fields["issuedBefore"]),
+		LocationID:   decodeValue[string](fields["locationID"]),
+	}
+}
+
+// RootQueryGreenWindowsArgs are the arguments of RootQuery.greenWindows
+type RootQueryGreenWindowsArgs struct {
+	DurationHours int
+	// Number of hours ahead to look for windows
+	Horizon    int
+	Limit      int
+	Load       LoadProfileInput
+	LocationID string
+	Solar      *SolarSystemInput
+	Turbine    *WindTurbineInput
+}
+
+// decodeRootQueryGreenWindowsArgs converts the values graphql-go coerced, defaults included, to RootQueryGreenWindowsArgs
+func decodeRootQueryGreenWindowsArgs(value interface{}) RootQueryGreenWindowsArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryGreenWindowsArgs{
+		DurationHours: decodeValue[int](fields["durationHours"]),
+		Horizon:       decodeValue[int](fields["horizon"]),
+		Limit:         decodeValue[int](fields["limit"]),
+		Load:          decodeLoadProfileInput(fields["load"]),
+		LocationID:    decodeValue[string](fields["locationID"]),
+		Solar:         decodePointer(fields["solar"], decodeSolarSystemInput),
+		Turbine:       decodePointer(fields["turbine"], decodeWindTurbineInput),
+	}
+}
+
+// RootQueryHeatDemandForecastArgs are the arguments of RootQuery.heatDemandForecast
+type RootQueryHeatDemandForecastArgs struct {
+	Building   BuildingInput
+	LocationID string
+}
+
+// decodeRootQueryHeatDemandForecastArgs converts the values graphql-go coerced, defaults included, to RootQueryHeatDemandForecastArgs
+func decodeRootQueryHeatDemandForecastArgs(value interface{}) RootQueryHeatDemandForecastArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryHeatDemandForecastArgs{
+		Building:   decodeBuildingInput(fields["building"]),
+		LocationID: decodeValue[string](fields["locationID"]),
+	}
+}
+
+// RootQueryHistoricalWeatherArgs are the arguments of RootQuery.historicalWeather
+type RootQueryHistoricalWeatherArgs struct {
+	End        string
+	LocationID string
+	Metrics    []string
+	// daily (default) or hourly
+	Resolution string
+	Start      string
+}
+
+// decodeRootQueryHistoricalWeatherArgs converts the values graphql-go coerced, defaults included, to RootQueryHistoricalWeatherArgs
+func decodeRootQueryHistoricalWeatherArgs(value interface{}) RootQueryHistoricalWeatherArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryHistoricalWeatherArgs{
+		End:        decodeValue[string](fields["end"]),
+		LocationID: decodeValue[string](fields["locationID"]),
+		Metrics:    decodeList(fields["metrics"], decodeValue[string]),
+		Resolution: decodeValue[string](fields["resolution"]),
+		Start:      decodeValue[string](fields["start"]),
+	}
+}
+
+// RootQueryLocationArgs are the arguments of RootQuery.location
+type RootQueryLocationArgs struct {
+	ID string
+}
+
+// decodeRootQueryLocationArgs converts the values graphql-go coerced, defaults included, to RootQueryLocationArgs
+func decodeRootQueryLocationArgs(value interface{}) RootQueryLocationArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryLocationArgs{
+		ID: decodeValue[string](fields["id"]),
+	}
+}
+
+// RootQuerySolarForecastArgs are the arguments of RootQuery.solarForecast
+type RootQuerySolarForecastArgs struct {
+	Days       int
+	LocationID string
+	System     SolarSystemInput
+}
+
+// decodeRootQuerySolarForecastArgs converts the values graphql-go coerced, defaults included, to RootQuerySolarForecastArgs
+func decodeRootQuerySolarForecastArgs(value interface{}) RootQuerySolarForecastArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQuerySolarForecastArgs{
+		Days:       decodeValue[int](fields["days"]),
+		LocationID: decodeValue[string](fields["locationID"]),
+		System:     decodeSolarSystemInput(fields["system"]),
+	}
+}
+
+// RootQueryWeatherForLocationsArgs are the arguments of RootQuery.weatherForLocations
+type RootQueryWeatherForLocationsArgs struct {
+	// Overrides the precipitation unit of the preset
+	PrecipitationUnit *PrecipitationUnit
+	// Overrides the temperature unit of the preset
+	TemperatureUnit *TemperatureUnit
+	// Unit system preset, METRIC by default
+	Units UnitSystem
+	// Overrides the wind speed unit of the preset
+	WindSpeedUnit *WindSpeedUnit
+}
+
+// decodeRootQueryWeatherForLocationsArgs converts the values graphql-go coerced, defaults included, to RootQueryWeatherForLocationsArgs
+func decodeRootQueryWeatherForLocationsArgs(value interface{}) RootQueryWeatherForLocationsArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryWeatherForLocationsArgs{
+		PrecipitationUnit: decodeEnumPointer[PrecipitationUnit](fields["precipitationUnit"]),
+		TemperatureUnit:   decodeEnumPointer[TemperatureUnit](fields["temperatureUnit"]),
+		Units:             decodeEnum[UnitSystem](fields["units"]),
+		WindSpeedUnit:     decodeEnumPointer[WindSpeedUnit](fields["windSpeedUnit"]),
+	}
+}
+
+// RootQueryWebhookDeliveriesArgs are the arguments of RootQuery.webhookDeliveries
+type RootQueryWebhookDeliveriesArgs struct {
+	Limit     int
+	WebhookID *string
+}
+
+// decodeRootQueryWebhookDeliveriesArgs converts the values graphql-go coerced, defaults included, to RootQueryWebhookDeliveriesArgs
+func decodeRootQueryWebhookDeliveriesArgs(value interface{}) RootQueryWebhookDeliveriesArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryWebhookDeliveriesArgs{
+		Limit:     decodeValue[int](fields["limit"]),
+		WebhookID: decodeValuePointer[string](fields["webhookID"]),
+	}
+}
+
+// RootQueryWindPowerForecastArgs are the arguments of RootQuery.windPowerForecast
+type RootQueryWindPowerForecastArgs struct {
+	Days       int
+	LocationID string
+	Turbine    WindTurbineInput
+}
+
+// decodeRootQueryWindPowerForecastArgs converts the values graphql-go coerced, defaults included, to RootQueryWindPowerForecastArgs
+func decodeRootQueryWindPowerForecastArgs(value interface{}) RootQueryWindPowerForecastArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootQueryWindPowerForecastArgs{
+		Days:       decodeValue[int](fields["days"]),
+		LocationID: decodeValue[string](fields["locationID"]),
+		Turbine:    decodeWindTurbineInput(fields["turbine"]),
+	}
+}
+
+// RootSubscriptionWeatherUpdatesArgs are the arguments of RootSubscription.weatherUpdates
+type RootSubscriptionWeatherUpdatesArgs struct {
+	LocationIDs []string
+}
+
+// decodeRootSubscriptionWeatherUpdatesArgs converts the values graphql-go coerced, defaults included, to RootSubscriptionWeatherUpdatesArgs
+func decodeRootSubscriptionWeatherUpdatesArgs(value interface{}) RootSubscriptionWeatherUpdatesArgs {
+	fields, _ := value.(map[string]interface{})
+	return RootSubscriptionWeatherUpdatesArgs{
+		LocationIDs: decodeList(fields["locationIDs"], decodeValue[string]),
+	}
+}
+
+// WeatherConditionDescriptionArgs are the arguments of WeatherCondition.description
+type WeatherConditionDescriptionArgs struct {
+	// en or de, falling back to en
+	Language string
+}
+
+// decodeWeatherConditionDescriptionArgs converts the values graphql-go coerced, defaults included, to WeatherConditionDescriptionArgs
+func decodeWeatherConditionDescriptionArgs(value interface{}) WeatherConditionDescriptionArgs {
+	fields, _ := value.(map[string]interface{})
+	return WeatherConditionDescriptionArgs{
+		Language: decodeValue[string](fields["language"]),
+	}
+}
+
+// WeatherInfoDegreeDaysArgs are the arguments of WeatherInfo.degreeDays
+type WeatherInfoDegreeDaysArgs struct {
+	// Base temperature of heating degree days
+	Base float64
+	// Base temperature of cooling degree days
+	CoolingBase float64
+	// mean (default), metoffice or integration
+	Method string
+}
+
+// decodeWeatherInfoDegreeDaysArgs converts the values graphql-go coerced, defaults included, to WeatherInfoDegreeDaysArgs
+func decodeWeatherInfoDegreeDaysArgs(value interface{}) WeatherInfoDegreeDaysArgs {
+	fields, _ := value.(map[string]interface{})
+	return WeatherInfoDegreeDaysArgs{
+		Base:        decodeValue[float64](fields["base"]),
+		CoolingBase: decodeValue[float64](fields["coolingBase"]),
+		Method:      decodeValue[string](fields["method"]),
+	}
+}
+
+// ResolverRoot gives the resolvers of the fields the schema can't read from the Go models
+type ResolverRoot interface {
+	CurrentConditions() CurrentConditionsResolver
+	DailyData() DailyDataResolver
+	DailyForecast() DailyForecastResolver
+	DegreeDays() DegreeDaysResolver
+	Forecast() ForecastResolver
+	HistoricalWeather() HistoricalWeatherResolver
+	Location() LocationResolver
+	RootMutation() RootMutationResolver
+	RootQuery() RootQueryResolver
+	RootSubscription() RootSubscriptionResolver
+	WeatherCondition() WeatherConditionResolver
+	WeatherInfo() WeatherInfoResolver
+}
+
+// CurrentConditionsResolver resolves the fields of CurrentConditions that are not read from CurrentWeatherInfo
+type CurrentConditionsResolver interface {
+	// Condition resolves CurrentConditions.condition
+	//
+	// Decoded weather_code of the current weather
+	// Resolved because CurrentWeatherInfo has no condition field.
+	Condition(ctx context.Context, obj *CurrentWeatherInfo) (*WeatherCondition, error)
+
+	// UvIndexMax resolves CurrentConditions.uvIndexMax
+	//
+	// Maximum UV index of the current day
+	// Resolved because CurrentWeatherInfo.UvIndexMax does not hold a Float.
+	UvIndexMax(ctx context.Context, obj *CurrentWeatherInfo) (*float64, error)
+
+	// WindBeaufort resolves CurrentConditions.windBeaufort
+	//
+	// Beaufort force of the wind speed
+	// Resolved because CurrentWeatherInfo has no windBeaufort field.
+	WindBeaufort(ctx context.Context, obj *CurrentWeatherInfo) (*int, error)
+
+	// WindCompass resolves CurrentConditions.windCompass
+	//
+	// 16-point compass label of wind_direction_10m
+	// Resolved because CurrentWeatherInfo has no windCompass field.
+	WindCompass(ctx context.Context, obj *CurrentWeatherInfo) (*string, error)
+
+	// WindDirection resolves CurrentConditions.windDirection
+	//
+	// Direction the wind blows from, in degrees
+	// Resolved because CurrentWeatherInfo has no windDirection field.
+	WindDirection(ctx context.Context, obj *CurrentWeatherInfo) (*int, error)
+
+	// WindU resolves CurrentConditions.windU
+	//
+	// Eastward component of the wind, in the wind speed unit
+	// Resolved because CurrentWeatherInfo has no windU field.
+	WindU(ctx context.Context, obj *CurrentWeatherInfo) (*float64, error)
+
+	// WindV resolves CurrentConditions.windV
+	//
+	// Northward component of the wind, in the wind speed unit
+	// Resolved because CurrentWeatherInfo has no windV field.
+	WindV(ctx context.Context, obj *CurrentWeatherInfo) (*float64, error)
+}
+
+// DailyDataResolver resolves the fields of DailyData that are not read from DailyData
+type DailyDataResolver interface {
+	// Condition resolves DailyData.condition
+	//
+	// Decoded weather_code of each day
+	// Resolved because DailyData has no condition field.
+	Condition(ctx context.Context, obj *DailyData) ([]*WeatherCondition, error)
+
+	// WindBeaufort resolves DailyData.windBeaufort
+	//
+	// Beaufort force of wind_speed_10m_max
+	// Resolved because DailyData has no windBeaufort field.
+	WindBeaufort(ctx context.Context, obj *DailyData) ([]*int, error)
+
+	// WindCompass resolves DailyData.windCompass
+	//
+	// 16-point compass label of wind_direction_10m_dominant
+	// Resolved because DailyData has no windCompass field.
+	WindCompass(ctx context.Context, obj *DailyData) ([]*string, error)
+
+	// WindU resolves DailyData.windU
+	//
+	// Eastward component of wind_speed_10m_max blowing from wind_direction_10m_dominant
+	// Resolved because DailyData has no windU field.
+	WindU(ctx context.Context, obj *DailyData) ([]*float64, error)
+
+	// WindV resolves DailyData.windV
+	//
+	// Northward component of wind_speed_10m_max blowing from wind_direction_10m_dominant
+	// Resolved because DailyData has no windV field.
+	WindV(ctx context.Context, obj *DailyData) ([]*float64, error)
+}
+
+// DailyForecastResolver resolves the fields of DailyForecast that are not read from DailyForecast
+type DailyForecastResolver interface {
+	// Condition resolves DailyForecast.condition
+	//
+	// Resolved because DailyForecast has no condition field.
+	Condition(ctx context.Context, obj *DailyForecast) (*WeatherCondition, error)
+
+	// WindBeaufort resolves DailyForecast.windBeaufort
+	//
+	// Resolved because DailyForecast has no windBeaufort field.
+	WindBeaufort(ctx context.Context, obj *DailyForecast) (*int, error)
+
+	// WindCompass resolves DailyForecast.windCompass
+	//
+	// Resolved because DailyForecast has no windCompass field.
+	WindCompass(ctx context.Context, obj *DailyForecast) (*string, error)
+}
+
+// DegreeDaysResolver resolves the fields of DegreeDays that are not read from DegreeDays
+type DegreeDaysResolver interface {
+	// SeasonToDate resolves DegreeDays.seasonToDate
+	//
+	// Degree days since the start of the heating season (October 1st), completed with observed weather
+	// Resolved because DegreeDays has no seasonToDate field.
+	SeasonToDate(ctx context.Context, obj *DegreeDays) (*DegreeDayAggregate, error)
+}
+
+// ForecastResolver resolves the fields of Forecast that are not read from WeatherForecastInfo
+type ForecastResolver interface {
+	// Days resolves Forecast.days
+	//
+	// Daily forecast with one entry per day
+	// Resolved because WeatherForecastInfo has no days field.
+	Days(ctx context.Context, obj *WeatherForecastInfo) ([]*DailyForecast, error)
+
+	// DegreeDays resolves Forecast.degreeDays
+	//
+	// Heating and cooling degree days of the forecast
+	DegreeDays(ctx context.Context, obj *WeatherForecastInfo, args ForecastDegreeDaysArgs) (*DegreeDays, error)
+
+	// Hours resolves Forecast.hours
+	//
+	// Hourly forecast with one entry per hour
+	// Resolved because WeatherForecastInfo has no hours field.
+	Hours(ctx context.Context, obj *WeatherForecastInfo) ([]*HourlyForecast, error)
+}
+
+// HistoricalWeatherResolver resolves the fields of HistoricalWeather that are not read from HistoricalWeather
+type HistoricalWeatherResolver interface {
+	// DegreeDays resolves HistoricalWeather.degreeDays
+	//
+	// Heating and cooling degree days of the observed weather
+	DegreeDays(ctx context.Context, obj *HistoricalWeather, args HistoricalWeatherDegreeDaysArgs) (*DegreeDays, error)
+}
+
+// LocationResolver resolves the fields of Location that are not read from Location
+type LocationResolver interface {
+	// Current resolves Location.current
+	//
+	// Current weather of the location, fetched only when selected
+	Current(ctx context.Context, obj *Location, args LocationCurrentArgs) (func() (*CurrentWeatherInfo, error), error)
+
+	// Forecast resolves Location.forecast
+	//
+	// Forecast of the location, fetched only when selected
+	Forecast(ctx context.Context, obj *Location, args LocationForecastArgs) (func() (*WeatherForecastInfo, error), error)
+}
+
+// RootMutationResolver resolves the fields of RootMutation
+type RootMutationResolver interface {
+	// AcknowledgeAlert resolves RootMutation.acknowledgeAlert
+	//
+	// Mark an alert of the feed as seen
+	AcknowledgeAlert(ctx context.Context, args RootMutationAcknowledgeAlertArgs) (*Alert, error)
+
+	// AddLocation resolves RootMutation.addLocation
+	//
+	// Add a new location
+	AddLocation(ctx context.Context, args RootMutationAddLocationArgs) (*Location, error)
+
+	// CreateAlertRule resolves RootMutation.createAlertRule
+	//
+	// Create an alert rule for a location, a group of locations or all of them
+	CreateAlertRule(ctx context.Context, args RootMutationCreateAlertRuleArgs) (*AlertRule, error)
+
+	// CreateWebhook resolves RootMutation.createWebhook
+	//
+	// Subscribe a URL to location and forecast events
+	CreateWebhook(ctx context.Context, args RootMutationCreateWebhookArgs) (*Webhook, error)
+
+	// DeleteAlertRule resolves RootMutation.deleteAlertRule
+	//
+	// Delete an alert rule by ID, keeping its past alerts
+	DeleteAlertRule(ctx context.Context, args RootMutationDeleteAlertRuleArgs) (*AlertRule, error)
+
+	// DeleteLocation resolves RootMutation.deleteLocation
+	//
+	// Delete a location by ID
+	DeleteLocation(ctx context.Context, args RootMutationDeleteLocationArgs) (*Location, error)
+
+	// DeleteWebhook resolves RootMutation.deleteWebhook
+	//
+	// Delete a webhook by ID, keeping its delivery log
+	DeleteWebhook(ctx context.Context, args RootMutationDeleteWebhookArgs) (*Webhook, error)
+
+	// SetWebhookEnabled resolves RootMutation.setWebhookEnabled
+	//
+	// Pause or resume the deliveries to a webhook
+	SetWebhookEnabled(ctx context.Context, args RootMutationSetWebhookEnabledArgs) (*Webhook, error)
+
+	// TestWebhook resolves RootMutation.testWebhook
+	//
+	// Send a webhook.test event to a webhook right away and return the outcome
+	TestWebhook(ctx context.Context, args RootMutationTestWebhookArgs) (*WebhookDelivery, error)
+
+	// UpdateAlertRule resolves RootMutation.updateAlertRule
+	//
+	// Replace an alert rule by ID
+	UpdateAlertRule(ctx context.Context, args RootMutationUpdateAlertRuleArgs) (*AlertRule, error)
+
+	// UpdateLocation resolves RootMutation.updateLocation
+	//
+	// Rename a location by ID
+	UpdateLocation(ctx context.Context, args RootMutationUpdateLocationArgs) (*Location, error)
+}
+
+// RootQueryResolver resolves the fields of RootQuery
+type RootQueryResolver interface {
+	// WeatherForecast resolves RootQuery.WeatherForecast
+	//
+	// Get weather forecast for a specific location
+	WeatherForecast(ctx context.Context, args RootQueryWeatherForecastArgs) (*WeatherInfo, error)
+
+	// AlertRules resolves RootQuery.alertRules
+	//
+	// Get every alert rule
+	AlertRules(ctx context.Context) ([]*AlertRule, error)
+
+	// Alerts resolves RootQuery.alerts
+	//
+	// Get the history of triggered alerts, newest first, which also serves as the in-app feed
+	Alerts(ctx context.Context, args RootQueryAlertsArgs) ([]*Alert, error)
+
+	// ForecastAccuracy resolves RootQuery.forecastAccuracy
+	//
+	// Get how far daily forecasts were off from observed values, per location, metric and lead time
+	ForecastAccuracy(ctx context.Context, args RootQueryForecastAccuracyArgs) ([]*ForecastAccuracy, error)
+
+	// ForecastHistory resolves RootQuery.forecastHistory
+	//
+	// Get the forecasts issued for a location within a time range, oldest first
+	ForecastHistory(ctx context.Context, args RootQueryForecastHistoryArgs) ([]*WeatherInfo, error)
+
+	// GreenWindows resolves RootQuery.greenWindows
+	//
+	// Rank the time windows in which a flexible load would best run on local solar and wind surplus
+	GreenWindows(ctx context.Context, args RootQueryGreenWindowsArgs) ([]*GreenWindow, error)
+
+	// HeatDemandForecast resolves RootQuery.heatDemandForecast
+	//
+	// Forecast the heating load and heat pump consumption of a building at a location over the next 7 days
+	HeatDemandForecast(ctx context.Context, args RootQueryHeatDemandForecastArgs) (*HeatDemandForecast, error)
+
+	// HistoricalWeather resolves RootQuery.historicalWeather
+	//
+	// Get observed weather for a location between two dates (YYYY-MM-DD), both included
+	HistoricalWeather(ctx context.Context, args RootQueryHistoricalWeatherArgs) (*HistoricalWeather, error)
+
+	// Location resolves RootQuery.location
+	//
+	// Get a location by ID
+	Location(ctx context.Context, args RootQueryLocationArgs) (*Location, error)
+
+	// Locations resolves RootQuery.locations
+	//
+	// Get all locations
+	Locations(ctx context.Context) ([]*Location, error)
+
+	// SolarForecast resolves RootQuery.solarForecast
+	//
+	// Estimate the energy produced by a photovoltaic system at a location over the next days
+	SolarForecast(ctx context.Context, args RootQuerySolarForecastArgs) (*SolarForecast, error)
+
+	// WeatherForLocations resolves RootQuery.weatherForLocations
+	//
+	// Get current weather for a list of locations
+	WeatherForLocations(ctx context.Context, args RootQueryWeatherForLocationsArgs) ([]*WeatherInfo, error)
+
+	// WebhookDeliveries resolves RootQuery.webhookDeliveries
+	//
+	// Get the delivery log of a webhook, or of every webhook, newest first
+	WebhookDeliveries(ctx context.Context, args RootQueryWebhookDeliveriesArgs) ([]*WebhookDelivery, error)
+
+	// Webhooks resolves RootQuery.webhooks
+	//
+	// Get every webhook
+	Webhooks(ctx context.Context) ([]*Webhook, error)
+
+	// WindPowerForecast resolves RootQuery.windPowerForecast
+	//
+	// Estimate the energy produced by a wind turbine at a location over the next days
+	WindPowerForecast(ctx context.Context, args RootQueryWindPowerForecastArgs) (*WindPowerForecast, error)
+}
+
+// RootSubscriptionResolver resolves the fields of RootSubscription
+type RootSubscriptionResolver interface {
+	// WeatherUpdates resolves RootSubscription.weatherUpdates
+	//
+	// Receive current weather whenever it changes, for one location, a group of locations or all of them
+	WeatherUpdates(ctx context.Context, args RootSubscriptionWeatherUpdatesArgs) (<-chan *WeatherInfo, error)
+}
+
+// WeatherConditionResolver resolves the fields of WeatherCondition that are not read from WeatherCondition
+type WeatherConditionResolver interface {
+	// Description resolves WeatherCondition.description
+	Description(ctx context.Context, obj *WeatherCondition, args WeatherConditionDescriptionArgs) (*string, error)
+}
+
+// WeatherInfoResolver resolves the fields of WeatherInfo that are not read from WeatherInfo
+type WeatherInfoResolver interface {
+	// CloudCoverage resolves WeatherInfo.cloudCoverage
+	//
+	// Resolved because WeatherInfo has no cloudCoverage field.
+	CloudCoverage(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// Condition resolves WeatherInfo.condition
+	//
+	// Decoded weather_code of the current weather
+	// Resolved because WeatherInfo has no condition field.
+	Condition(ctx context.Context, obj *WeatherInfo) (*WeatherCondition, error)
+
+	// Daily resolves WeatherInfo.daily
+	//
+	// Resolved because WeatherInfo has no daily field.
+	Daily(ctx context.Context, obj *WeatherInfo) (*DailyData, error)
+
+	// DailyUnits resolves WeatherInfo.dailyUnits
+	//
+	// Resolved because WeatherInfo has no dailyUnits field.
+	DailyUnits(ctx context.Context, obj *WeatherInfo) (*Units, error)
+
+	// Days resolves WeatherInfo.days
+	//
+	// Daily forecast with one entry per day
+	// Resolved because WeatherInfo has no days field.
+	Days(ctx context.Context, obj *WeatherInfo) ([]*DailyForecast, error)
+
+	// DegreeDays resolves WeatherInfo.degreeDays
+	//
+	// Heating and cooling degree days of the forecast
+	DegreeDays(ctx context.Context, obj *WeatherInfo, args WeatherInfoDegreeDaysArgs) (*DegreeDays, error)
+
+	// Hourly resolves WeatherInfo.hourly
+	//
+	// Resolved because WeatherInfo has no hourly field.
+	Hourly(ctx context.Context, obj *WeatherInfo) (*HourlyData, error)
+
+	// HourlyUnits resolves WeatherInfo.hourlyUnits
+	//
+	// Resolved because WeatherInfo has no hourlyUnits field.
+	HourlyUnits(ctx context.Context, obj *WeatherInfo) (*Units, error)
+
+	// Hours resolves WeatherInfo.hours
+	//
+	// Hourly forecast with one entry per hour
+	// Resolved because WeatherInfo has no hours field.
+	Hours(ctx context.Context, obj *WeatherInfo) ([]*HourlyForecast, error)
+
+	// MaxTemperature resolves WeatherInfo.maxTemperature
+	//
+	// Maximum temperature of the current day
+	// Resolved because WeatherInfo has no maxTemperature field.
+	MaxTemperature(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// MinTemperature resolves WeatherInfo.minTemperature
+	//
+	// Minimum temperature of the current day
+	// Resolved because WeatherInfo has no minTemperature field.
+	MinTemperature(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// Temperature resolves WeatherInfo.temperature
+	//
+	// Resolved because WeatherInfo has no temperature field.
+	Temperature(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// Units resolves WeatherInfo.units
+	//
+	// Resolved because WeatherInfo has no units field.
+	Units(ctx context.Context, obj *WeatherInfo) (*Units, error)
+
+	// UvIndex resolves WeatherInfo.uvIndex
+	//
+	// Resolved because WeatherInfo has no uvIndex field.
+	UvIndex(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// UvIndexMax resolves WeatherInfo.uvIndexMax
+	//
+	// Maximum UV index of the current day
+	// Resolved because WeatherInfo has no uvIndexMax field.
+	UvIndexMax(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// WeatherCode resolves WeatherInfo.weatherCode
+	//
+	// WMO weather code of the current weather
+	// Resolved because WeatherInfo has no weatherCode field.
+	WeatherCode(ctx context.Context, obj *WeatherInfo) (*int, error)
+
+	// Weather_code resolves WeatherInfo.weather_code
+	//
+	// Resolved because WeatherInfo has no weather_code field.
+	Weather_code(ctx context.Context, obj *WeatherInfo) (*int, error)
+
+	// WindBeaufort resolves WeatherInfo.windBeaufort
+	//
+	// Beaufort force of the wind speed
+	// Resolved because WeatherInfo has no windBeaufort field.
+	WindBeaufort(ctx context.Context, obj *WeatherInfo) (*int, error)
+
+	// WindCompass resolves WeatherInfo.windCompass
+	//
+	// 16-point compass label of wind_direction_10m
+	// Resolved because WeatherInfo has no windCompass field.
+	WindCompass(ctx context.Context, obj *WeatherInfo) (*string, error)
+
+	// WindDirection resolves WeatherInfo.windDirection
+	//
+	// Direction the wind blows from, in degrees
+	// Resolved because WeatherInfo has no windDirection field.
+	WindDirection(ctx context.Context, obj *WeatherInfo) (*int, error)
+
+	// WindSpeed resolves WeatherInfo.windSpeed
+	//
+	// Resolved because WeatherInfo has no windSpeed field.
+	WindSpeed(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// WindU resolves WeatherInfo.windU
+	//
+	// Eastward component of the wind, in the wind speed unit
+	// Resolved because WeatherInfo has no windU field.
+	WindU(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// WindV resolves WeatherInfo.windV
+	//
+	// Northward component of the wind, in the wind speed unit
+	// Resolved because WeatherInfo has no windV field.
+	WindV(ctx context.Context, obj *WeatherInfo) (*float64, error)
+
+	// WindDirection10m resolves WeatherInfo.wind_direction_10m
+	//
+	// Resolved because WeatherInfo has no wind_direction_10m field.
+	WindDirection10m(ctx context.Context, obj *WeatherInfo) (*int, error)
+}
+
+// schemaResolvers resolves every field of the schema, keyed by Type.field
+func schemaResolvers(r ResolverRoot) map[string]fieldResolver {
+	return map[string]fieldResolver{
+		"Alert.acknowledged":              {Resolve: structField(func(obj *Alert) interface{} { return obj.Acknowledged }), ReadsStruct: true},
+		"Alert.deliveries":                {Resolve: structField(func(obj *Alert) interface{} { return obj.Deliveries }), ReadsStruct: true},
+		"Alert.forecastTime":              {Resolve: structField(func(obj *Alert) interface{} { return obj.ForecastTime }), ReadsStruct: true},
+		"Alert.id":                        {Resolve: structField(func(obj *Alert) interface{} { return obj.ID }), ReadsStruct: true},
+		"Alert.locationID":                {Resolve: structField(func(obj *Alert) interface{} { return obj.LocationID }), ReadsStruct: true},
+		"Alert.locationName":              {Resolve: structField(func(obj *Alert) interface{} { return obj.LocationName }), ReadsStruct: true},
+		"Alert.message":                   {Resolve: structField(func(obj *Alert) interface{} { return obj.Message }), ReadsStruct: true},
+		"Alert.metric":                    {Resolve: structField(func(obj *Alert) interface{} { return obj.Metric }), ReadsStruct: true},
+		"Alert.operator":                  {Resolve: structField(func(obj *Alert) interface{} { return obj.Operator }), ReadsStruct: true},
+		"Alert.ruleID":                    {Resolve: structField(func(obj *Alert) interface{} { return obj.RuleID }), ReadsStruct: true},
+		"Alert.ruleName":                  {Resolve: structField(func(obj *Alert) interface{} { return obj.RuleName }), ReadsStruct: true},
+		"Alert.threshold":                 {Resolve: structField(func(obj *Alert) interface{} { return obj.Threshold }), ReadsStruct: true},
+		"Alert.triggeredAt":               {Resolve: structField(func(obj *Alert) interface{} { return obj.TriggeredAt }), ReadsStruct: true},
+		"Alert.unit":                      {Resolve: structField(func(obj *Alert) interface{} { return obj.Unit }), ReadsStruct: true},
+		"Alert.value":                     {Resolve: structField(func(obj *Alert) interface{} { return obj.Value }), ReadsStruct: true},
+		"AlertDelivery.at":                {Resolve: structField(func(obj *AlertDelivery) interface{} { return obj.At }), ReadsStruct: true},
+		"AlertDelivery.channel":           {Resolve: structField(func(obj *AlertDelivery) interface{} { return obj.Channel }), ReadsStruct: true},
+		"AlertDelivery.error":             {Resolve: structField(func(obj *AlertDelivery) interface{} { return obj.Error }), ReadsStruct: true},
+		"AlertDelivery.status":            {Resolve: structField(func(obj *AlertDelivery) interface{} { return obj.Status }), ReadsStruct: true},
+		"AlertRule.channels":              {Resolve: structField(func(obj *AlertRule) interface{} { return obj.Channels }), ReadsStruct: true},
+		"AlertRule.cooldownMinutes":       {Resolve: structField(func(obj *AlertRule) interface{} { return obj.CooldownMinutes }), ReadsStruct: true},
+		"AlertRule.createdAt":             {Resolve: structField(func(obj *AlertRule) interface{} { return obj.CreatedAt }), ReadsStruct: true},
+		"AlertRule.email":                 {Resolve: structField(func(obj *AlertRule) interface{} { return obj.Email }), ReadsStruct: true},
+		"AlertRule.enabled":               {Resolve: structField(func(obj *AlertRule) interface{} { return obj.Enabled }), ReadsStruct: true},
+		"AlertRule.horizonHours":          {Resolve: structField(func(obj *AlertRule) interface{} { return obj.HorizonHours }), ReadsStruct: true},
+		"AlertRule.id":                    {Resolve: structField(func(obj *AlertRule) interface{} { return obj.ID }), ReadsStruct: true},
+		"AlertRule.locationIDs":           {Resolve: structField(func(obj *AlertRule) interface{} { return obj.LocationIDs }), ReadsStruct: true},
+		"AlertRule.metric":                {Resolve: structField(func(obj *AlertRule) interface{} { return obj.Metric }), ReadsStruct: true},
+		"AlertRule.name":                  {Resolve: structField(func(obj *AlertRule) interface{} { return obj.Name }), ReadsStruct: true},
+		"AlertRule.operator":              {Resolve: structField(func(obj *AlertRule) interface{} { return obj.Operator }), ReadsStruct: true},
+		"AlertRule.threshold":             {Resolve: structField(func(obj *AlertRule) interface{} { return obj.Threshold }), ReadsStruct: true},
+		"AlertRule.updatedAt":             {Resolve: structField(func(obj *AlertRule) interface{} { return obj.UpdatedAt }), ReadsStruct: true},
+		"AlertRule.webhookURL":            {Resolve: structField(func(obj *AlertRule) interface{} { return obj.WebhookURL }), ReadsStruct: true},
+		"Building.copCurve":               {Resolve: structField(func(obj *Building) interface{} { return obj.COPCurve }), ReadsStruct: true},
+		"Building.flowTemperature":        {Resolve: structField(func(obj *Building) interface{} { return obj.FlowTemperature }), ReadsStruct: true},
+		"Building.heatLossCoefficient":    {Resolve: structField(func(obj *Building) interface{} { return obj.HeatLossCoefficient }), ReadsStruct: true},
+		"Building.setpoint":               {Resolve: structField(func(obj *Building) interface{} { return obj.Setpoint }), ReadsStruct: true},
+		"COPPoint.cop":                    {Resolve: structField(func(obj *COPPoint) interface{} { return obj.COP }), ReadsStruct: true},
+		"COPPoint.outdoorTemperature":     {Resolve: structField(func(obj *COPPoint) interface{} { return obj.OutdoorTemperature }), ReadsStruct: true},
+		"CurrentConditions.cloudCoverage": {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.CloudCoverage }), ReadsStruct: true},
+		"CurrentConditions.condition": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[CurrentWeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.CurrentConditions().Condition(p.Context, obj))
+			},
+		},
+		"CurrentConditions.fetchedAt":      {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.FetchedAt }), ReadsStruct: true},
+		"CurrentConditions.maxTemperature": {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.MaxTemperature }), ReadsStruct: true},
+		"CurrentConditions.minTemperature": {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.MinTemperature }), ReadsStruct: true},
+		"CurrentConditions.temperature":    {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.Temperature }), ReadsStruct: true},
+		"CurrentConditions.units":          {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.Units }), ReadsStruct: true},
+		"CurrentConditions.uvIndex":        {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.UvIndex }), ReadsStruct: true},
+		"CurrentConditions.uvIndexMax": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[CurrentWeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.CurrentConditions().UvIndexMax(p.Context, obj))
+			},
+		},
+		"CurrentConditions.weatherCode": {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.WeatherCode }), ReadsStruct: true},
+		"CurrentConditions.windBeaufort": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[CurrentWeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.CurrentConditions().WindBeaufort(p.Context, obj))
+			},
+		},
+		"CurrentConditions.windCompass": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[CurrentWeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.CurrentConditions().WindCompass(p.Context, obj))
+			},
+		},
+		"CurrentConditions.windDirection": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[CurrentWeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.CurrentConditions().WindDirection(p.Context, obj))
+			},
+		},
+		"CurrentConditions.windSpeed": {Resolve: structField(func(obj *CurrentWeatherInfo) interface{} { return obj.WindSpeed }), ReadsStruct: true},
+		"CurrentConditions.windU": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[CurrentWeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.CurrentConditions().WindU(p.Context, obj))
+			},
+		},
+		"CurrentConditions.windV": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[CurrentWeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.CurrentConditions().WindV(p.Context, obj))
+			},
+		},
+		"DailyData.condition": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyData](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyData().Condition(p.Context, obj))
+			},
+		},
+		"DailyData.precipitation_sum":  {Resolve: structField(func(obj *DailyData) interface{} { return obj.PrecipitationSum }), ReadsStruct: true},
+		"DailyData.temperature_2m_max": {Resolve: structField(func(obj *DailyData) interface{} { return obj.Temperature2mMax }), ReadsStruct: true},
+		"DailyData.temperature_2m_min": {Resolve: structField(func(obj *DailyData) interface{} { return obj.Temperature2mMin }), ReadsStruct: true},
+		"DailyData.time":               {Resolve: structField(func(obj *DailyData) interface{} { return obj.Time }), ReadsStruct: true},
+		"DailyData.uv_index_max":       {Resolve: structField(func(obj *DailyData) interface{} { return obj.UvIndexMax }), ReadsStruct: true},
+		"DailyData.weather_code":       {Resolve: structField(func(obj *DailyData) interface{} { return obj.WeatherCode }), ReadsStruct: true},
+		"DailyData.windBeaufort": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyData](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyData().WindBeaufort(p.Context, obj))
+			},
+		},
+		"DailyData.windCompass": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyData](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyData().WindCompass(p.Context, obj))
+			},
+		},
+		"DailyData.windU": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyData](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyData().WindU(p.Context, obj))
+			},
+		},
+		"DailyData.windV": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyData](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyData().WindV(p.Context, obj))
+			},
+		},
+		"DailyData.wind_direction_10m_dominant": {Resolve: structField(func(obj *DailyData) interface{} { return obj.WindDirectionAngle }), ReadsStruct: true},
+		"DailyData.wind_speed_10m_max":          {Resolve: structField(func(obj *DailyData) interface{} { return obj.WindSpeed10mMax }), ReadsStruct: true},
+		"DailyForecast.condition": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyForecast](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyForecast().Condition(p.Context, obj))
+			},
+		},
+		"DailyForecast.date":             {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.Date }), ReadsStruct: true},
+		"DailyForecast.precipitationSum": {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.PrecipitationSum }), ReadsStruct: true},
+		"DailyForecast.temperatureMax":   {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.TemperatureMax }), ReadsStruct: true},
+		"DailyForecast.temperatureMin":   {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.TemperatureMin }), ReadsStruct: true},
+		"DailyForecast.uvIndexMax":       {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.UvIndexMax }), ReadsStruct: true},
+		"DailyForecast.weatherCode":      {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.WeatherCode }), ReadsStruct: true},
+		"DailyForecast.windBeaufort": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyForecast](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyForecast().WindBeaufort(p.Context, obj))
+			},
+		},
+		"DailyForecast.windCompass": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DailyForecast](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DailyForecast().WindCompass(p.Context, obj))
+			},
+		},
+		"DailyForecast.windDirection": {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.WindDirection }), ReadsStruct: true},
+		"DailyForecast.windSpeedMax":  {Resolve: structField(func(obj *DailyForecast) interface{} { return obj.WindSpeedMax }), ReadsStruct: true},
+		"DegreeDay.cdd":               {Resolve: structField(func(obj *DegreeDay) interface{} { return obj.CDD }), ReadsStruct: true},
+		"DegreeDay.date":              {Resolve: structField(func(obj *DegreeDay) interface{} { return obj.Date }), ReadsStruct: true},
+		"DegreeDay.hdd":               {Resolve: structField(func(obj *DegreeDay) interface{} { return obj.HDD }), ReadsStruct: true},
+		"DegreeDayAggregate.cdd":      {Resolve: structField(func(obj *DegreeDayAggregate) interface{} { return obj.CDD }), ReadsStruct: true},
+		"DegreeDayAggregate.days":     {Resolve: structField(func(obj *DegreeDayAggregate) interface{} { return obj.Days }), ReadsStruct: true},
+		"DegreeDayAggregate.end":      {Resolve: structField(func(obj *DegreeDayAggregate) interface{} { return obj.End }), ReadsStruct: true},
+		"DegreeDayAggregate.hdd":      {Resolve: structField(func(obj *DegreeDayAggregate) interface{} { return obj.HDD }), ReadsStruct: true},
+		"DegreeDayAggregate.period":   {Resolve: structField(func(obj *DegreeDayAggregate) interface{} { return obj.Period }), ReadsStruct: true},
+		"DegreeDayAggregate.start":    {Resolve: structField(func(obj *DegreeDayAggregate) interface{} { return obj.Start }), ReadsStruct: true},
+		"DegreeDays.base":             {Resolve: structField(func(obj *DegreeDays) interface{} { return obj.Base }), ReadsStruct: true},
+		"DegreeDays.coolingBase":      {Resolve: structField(func(obj *DegreeDays) interface{} { return obj.CoolingBase }), ReadsStruct: true},
+		"DegreeDays.daily":            {Resolve: structField(func(obj *DegreeDays) interface{} { return obj.Daily }), ReadsStruct: true},
+		"DegreeDays.method":           {Resolve: structField(func(obj *DegreeDays) interface{} { return obj.Method }), ReadsStruct: true},
+		"DegreeDays.monthly":          {Resolve: structField(func(obj *DegreeDays) interface{} { return obj.Monthly }), ReadsStruct: true},
+		"DegreeDays.seasonToDate": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[DegreeDays](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.DegreeDays().SeasonToDate(p.Context, obj))
+			},
+		},
+		"DegreeDays.totalCDD": {Resolve: structField(func(obj *DegreeDays) interface{} { return obj.TotalCDD }), ReadsStruct: true},
+		"DegreeDays.totalHDD": {Resolve: structField(func(obj *DegreeDays) interface{} { return obj.TotalHDD }), ReadsStruct: true},
+		"Forecast.daily":      {Resolve: structField(func(obj *WeatherForecastInfo) interface{} { return obj.Daily }), ReadsStruct: true},
+		"Forecast.dailyUnits": {Resolve: structField(func(obj *WeatherForecastInfo) interface{} { return obj.DailyUnits }), ReadsStruct: true},
+		"Forecast.days": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherForecastInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.Forecast().Days(p.Context, obj))
+			},
+		},
+		"Forecast.degreeDays": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherForecastInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.Forecast().DegreeDays(p.Context, obj, decodeForecastDegreeDaysArgs(p.Args)))
+			},
+		},
+		"Forecast.fetchedAt":   {Resolve: structField(func(obj *WeatherForecastInfo) interface{} { return obj.FetchedAt }), ReadsStruct: true},
+		"Forecast.hourly":      {Resolve: structField(func(obj *WeatherForecastInfo) interface{} { return obj.Hourly }), ReadsStruct: true},
+		"Forecast.hourlyUnits": {Resolve: structField(func(obj *WeatherForecastInfo) interface{} { return obj.HourlyUnits }), ReadsStruct: true},
+		"Forecast.hours": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherForecastInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.Forecast().Hours(p.Context, obj))
+			},
+		},
+		"ForecastAccuracy.bias":                  {Resolve: structField(func(obj *ForecastAccuracy) interface{} { return obj.Bias }), ReadsStruct: true},
+		"ForecastAccuracy.leadDays":              {Resolve: structField(func(obj *ForecastAccuracy) interface{} { return obj.LeadDays }), ReadsStruct: true},
+		"ForecastAccuracy.locationID":            {Resolve: structField(func(obj *ForecastAccuracy) interface{} { return obj.LocationID }), ReadsStruct: true},
+		"ForecastAccuracy.mae":                   {Resolve: structField(func(obj *ForecastAccuracy) interface{} { return obj.MAE }), ReadsStruct: true},
+		"ForecastAccuracy.metric":                {Resolve: structField(func(obj *ForecastAccuracy) interface{} { return obj.Metric }), ReadsStruct: true},
+		"ForecastAccuracy.samples":               {Resolve: structField(func(obj *ForecastAccuracy) interface{} { return obj.Samples }), ReadsStruct: true},
+		"ForecastAccuracy.verifiedAt":            {Resolve: structField(func(obj *ForecastAccuracy) interface{} { return obj.VerifiedAt }), ReadsStruct: true},
+		"GreenWindow.coverage":                   {Resolve: structField(func(obj *GreenWindow) interface{} { return obj.Coverage }), ReadsStruct: true},
+		"GreenWindow.end":                        {Resolve: structField(func(obj *GreenWindow) interface{} { return obj.End }), ReadsStruct: true},
+		"GreenWindow.loadKwh":                    {Resolve: structField(func(obj *GreenWindow) interface{} { return obj.LoadKwh }), ReadsStruct: true},
+		"GreenWindow.rank":                       {Resolve: structField(func(obj *GreenWindow) interface{} { return obj.Rank }), ReadsStruct: true},
+		"GreenWindow.renewableKwh":               {Resolve: structField(func(obj *GreenWindow) interface{} { return obj.RenewableKwh }), ReadsStruct: true},
+		"GreenWindow.start":                      {Resolve: structField(func(obj *GreenWindow) interface{} { return obj.Start }), ReadsStruct: true},
+		"HeatDemandDay.cop":                      {Resolve: structField(func(obj *HeatDemandDay) interface{} { return obj.COP }), ReadsStruct: true},
+		"HeatDemandDay.date":                     {Resolve: structField(func(obj *HeatDemandDay) interface{} { return obj.Date }), ReadsStruct: true},
+		"HeatDemandDay.electricityKwh":           {Resolve: structField(func(obj *HeatDemandDay) interface{} { return obj.ElectricityKwh }), ReadsStruct: true},
+		"HeatDemandDay.heatKwh":                  {Resolve: structField(func(obj *HeatDemandDay) interface{} { return obj.HeatKwh }), ReadsStruct: true},
+		"HeatDemandDay.peakLoadKw":               {Resolve: structField(func(obj *HeatDemandDay) interface{} { return obj.PeakLoadKw }), ReadsStruct: true},
+		"HeatDemandForecast.building":            {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.Building }), ReadsStruct: true},
+		"HeatDemandForecast.daily":               {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.Daily }), ReadsStruct: true},
+		"HeatDemandForecast.fetchedAt":           {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.FetchedAt }), ReadsStruct: true},
+		"HeatDemandForecast.hourly":              {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.Hourly }), ReadsStruct: true},
+		"HeatDemandForecast.id":                  {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.ID }), ReadsStruct: true},
+		"HeatDemandForecast.latitude":            {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.Latitude }), ReadsStruct: true},
+		"HeatDemandForecast.locationName":        {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.LocationName }), ReadsStruct: true},
+		"HeatDemandForecast.longitude":           {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.Longitude }), ReadsStruct: true},
+		"HeatDemandForecast.totalElectricityKwh": {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.TotalElectricityKwh }), ReadsStruct: true},
+		"HeatDemandForecast.totalHeatKwh":        {Resolve: structField(func(obj *HeatDemandForecast) interface{} { return obj.TotalHeatKwh }), ReadsStruct: true},
+		"HeatDemandHour.cop":                     {Resolve: structField(func(obj *HeatDemandHour) interface{} { return obj.COP }), ReadsStruct: true},
+		"HeatDemandHour.electricityKwh":          {Resolve: structField(func(obj *HeatDemandHour) interface{} { return obj.ElectricityKwh }), ReadsStruct: true},
+		"HeatDemandHour.heatKwh":                 {Resolve: structField(func(obj *HeatDemandHour) interface{} { return obj.HeatKwh }), ReadsStruct: true},
+		"HeatDemandHour.outdoorTemperature":      {Resolve: structField(func(obj *HeatDemandHour) interface{} { return obj.OutdoorTemperature }), ReadsStruct: true},
+		"HeatDemandHour.time":                    {Resolve: structField(func(obj *HeatDemandHour) interface{} { return obj.Time }), ReadsStruct: true},
+		"HistoricalWeather.daily":                {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.Daily }), ReadsStruct: true},
+		"HistoricalWeather.dailyUnits":           {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.DailyUnits }), ReadsStruct: true},
+		"HistoricalWeather.degreeDays": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[HistoricalWeather](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.HistoricalWeather().DegreeDays(p.Context, obj, decodeHistoricalWeatherDegreeDaysArgs(p.Args)))
+			},
+		},
+		"HistoricalWeather.end":          {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.End }), ReadsStruct: true},
+		"HistoricalWeather.hourly":       {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.Hourly }), ReadsStruct: true},
+		"HistoricalWeather.hourlyUnits":  {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.HourlyUnits }), ReadsStruct: true},
+		"HistoricalWeather.id":           {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.ID }), ReadsStruct: true},
+		"HistoricalWeather.latitude":     {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.Latitude }), ReadsStruct: true},
+		"HistoricalWeather.locationName": {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.LocationName }), ReadsStruct: true},
+		"HistoricalWeather.longitude":    {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.Longitude }), ReadsStruct: true},
+		"HistoricalWeather.resolution":   {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.Resolution }), ReadsStruct: true},
+		"HistoricalWeather.start":        {Resolve: structField(func(obj *HistoricalWeather) interface{} { return obj.Start }), ReadsStruct: true},
+		"HourlyData.cloudCover":          {Resolve: structField(func(obj *HourlyData) interface{} { return obj.CloudCover }), ReadsStruct: true},
+		"HourlyData.temperature2m":       {Resolve: structField(func(obj *HourlyData) interface{} { return obj.Temperature2m }), ReadsStruct: true},
+		"HourlyData.time":                {Resolve: structField(func(obj *HourlyData) interface{} { return obj.Time }), ReadsStruct: true},
+		"HourlyData.uvIndex":             {Resolve: structField(func(obj *HourlyData) interface{} { return obj.UvIndex }), ReadsStruct: true},
+		"HourlyData.windSpeed80m":        {Resolve: structField(func(obj *HourlyData) interface{} { return obj.WindSpeed80m }), ReadsStruct: true},
+		"HourlyForecast.cloudCover":      {Resolve: structField(func(obj *HourlyForecast) interface{} { return obj.CloudCover }), ReadsStruct: true},
+		"HourlyForecast.temperature":     {Resolve: structField(func(obj *HourlyForecast) interface{} { return obj.Temperature }), ReadsStruct: true},
+		"HourlyForecast.time":            {Resolve: structField(func(obj *HourlyForecast) interface{} { return obj.Time }), ReadsStruct: true},
+		"HourlyForecast.uvIndex":         {Resolve: structField(func(obj *HourlyForecast) interface{} { return obj.UvIndex }), ReadsStruct: true},
+		"HourlyForecast.windSpeed":       {Resolve: structField(func(obj *HourlyForecast) interface{} { return obj.WindSpeed }), ReadsStruct: true},
+		"Location.current": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[Location](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				load, err := r.Location().Current(p.Context, obj, decodeLocationCurrentArgs(p.Args))
+				if err != nil {
+					return nil, err
+				}
+				return func() (interface{}, error) { return resolved(load()) }, nil
+			},
+		},
+		"Location.forecast": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[Location](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				load, err := r.Location().Forecast(p.Context, obj, decodeLocationForecastArgs(p.Args))
+				if err != nil {
+					return nil, err
+				}
+				return func() (interface{}, error) { return resolved(load()) }, nil
+			},
+		},
+		"Location.id":             {Resolve: structField(func(obj *Location) interface{} { return obj.ID }), ReadsStruct: true},
+		"Location.latitude":       {Resolve: structField(func(obj *Location) interface{} { return obj.Latitude }), ReadsStruct: true},
+		"Location.longitude":      {Resolve: structField(func(obj *Location) interface{} { return obj.Longitude }), ReadsStruct: true},
+		"Location.name":           {Resolve: structField(func(obj *Location) interface{} { return obj.Name }), ReadsStruct: true},
+		"PowerCurvePoint.powerKw": {Resolve: structField(func(obj *PowerCurvePoint) interface{} { return obj.PowerKw }), ReadsStruct: true},
+		"PowerCurvePoint.speed":   {Resolve: structField(func(obj *PowerCurvePoint) interface{} { return obj.Speed }), ReadsStruct: true},
+		"RootMutation.acknowledgeAlert": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().AcknowledgeAlert(p.Context, decodeRootMutationAcknowledgeAlertArgs(p.Args)))
+			},
+		},
+		"RootMutation.addLocation": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().AddLocation(p.Context, decodeRootMutationAddLocationArgs(p.Args)))
+			},
+		},
+		"RootMutation.createAlertRule": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().CreateAlertRule(p.Context, decodeRootMutationCreateAlertRuleArgs(p.Args)))
+			},
+		},
+		"RootMutation.createWebhook": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().CreateWebhook(p.Context, decodeRootMutationCreateWebhookArgs(p.Args)))
+			},
+		},
+		"RootMutation.deleteAlertRule": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().DeleteAlertRule(p.Context, decodeRootMutationDeleteAlertRuleArgs(p.Args)))
+			},
+		},
+		"RootMutation.deleteLocation": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().DeleteLocation(p.Context, decodeRootMutationDeleteLocationArgs(p.Args)))
+			},
+		},
+		"RootMutation.deleteWebhook": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().DeleteWebhook(p.Context, decodeRootMutationDeleteWebhookArgs(p.Args)))
+			},
+		},
+		"RootMutation.setWebhookEnabled": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().SetWebhookEnabled(p.Context, decodeRootMutationSetWebhookEnabledArgs(p.Args)))
+			},
+		},
+		"RootMutation.testWebhook": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().TestWebhook(p.Context, decodeRootMutationTestWebhookArgs(p.Args)))
+			},
+		},
+		"RootMutation.updateAlertRule": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().UpdateAlertRule(p.Context, decodeRootMutationUpdateAlertRuleArgs(p.Args)))
+			},
+		},
+		"RootMutation.updateLocation": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootMutation().UpdateLocation(p.Context, decodeRootMutationUpdateLocationArgs(p.Args)))
+			},
+		},
+		"RootQuery.WeatherForecast": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().WeatherForecast(p.Context, decodeRootQueryWeatherForecastArgs(p.Args)))
+			},
+		},
+		"RootQuery.alertRules": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().AlertRules(p.Context))
+			},
+		},
+		"RootQuery.alerts": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().Alerts(p.Context, decodeRootQueryAlertsArgs(p.Args)))
+			},
+		},
+		"RootQuery.forecastAccuracy": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().ForecastAccuracy(p.Context, decodeRootQueryForecastAccuracyArgs(p.Args)))
+			},
+		},
+		"RootQuery.forecastHistory": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().ForecastHistory(p.Context, decodeRootQueryForecastHistoryArgs(p.Args)))
+			},
+		},
+		"RootQuery.greenWindows": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().GreenWindows(p.Context, decodeRootQueryGreenWindowsArgs(p.Args)))
+			},
+		},
+		"RootQuery.heatDemandForecast": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().HeatDemandForecast(p.Context, decodeRootQueryHeatDemandForecastArgs(p.Args)))
+			},
+		},
+		"RootQuery.historicalWeather": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().HistoricalWeather(p.Context, decodeRootQueryHistoricalWeatherArgs(p.Args)))
+			},
+		},
+		"RootQuery.location": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().Location(p.Context, decodeRootQueryLocationArgs(p.Args)))
+			},
+		},
+		"RootQuery.locations": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().Locations(p.Context))
+			},
+		},
+		"RootQuery.solarForecast": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().SolarForecast(p.Context, decodeRootQuerySolarForecastArgs(p.Args)))
+			},
+		},
+		"RootQuery.weatherForLocations": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().WeatherForLocations(p.Context, decodeRootQueryWeatherForLocationsArgs(p.Args)))
+			},
+		},
+		"RootQuery.webhookDeliveries": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().WebhookDeliveries(p.Context, decodeRootQueryWebhookDeliveriesArgs(p.Args)))
+			},
+		},
+		"RootQuery.webhooks": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().Webhooks(p.Context))
+			},
+		},
+		"RootQuery.windPowerForecast": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolved(r.RootQuery().WindPowerForecast(p.Context, decodeRootQueryWindPowerForecastArgs(p.Args)))
+			},
+		},
+		"RootSubscription.weatherUpdates": {
+			Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+				events, err := r.RootSubscription().WeatherUpdates(p.Context, decodeRootSubscriptionWeatherUpdatesArgs(p.Args))
+				if err != nil {
+					return nil, err
+				}
+				return forward(p.Context, events), nil
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source, nil
+			},
+		},
+		"SolarDay.date":              {Resolve: structField(func(obj *SolarDay) interface{} { return obj.Date }), ReadsStruct: true},
+		"SolarDay.energyKwh":         {Resolve: structField(func(obj *SolarDay) interface{} { return obj.EnergyKwh }), ReadsStruct: true},
+		"SolarDay.peakKw":            {Resolve: structField(func(obj *SolarDay) interface{} { return obj.PeakKw }), ReadsStruct: true},
+		"SolarForecast.daily":        {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.Daily }), ReadsStruct: true},
+		"SolarForecast.fetchedAt":    {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.FetchedAt }), ReadsStruct: true},
+		"SolarForecast.hourly":       {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.Hourly }), ReadsStruct: true},
+		"SolarForecast.id":           {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.ID }), ReadsStruct: true},
+		"SolarForecast.latitude":     {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.Latitude }), ReadsStruct: true},
+		"SolarForecast.locationName": {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.LocationName }), ReadsStruct: true},
+		"SolarForecast.longitude":    {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.Longitude }), ReadsStruct: true},
+		"SolarForecast.system":       {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.System }), ReadsStruct: true},
+		"SolarForecast.timezone":     {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.Timezone }), ReadsStruct: true},
+		"SolarForecast.totalKwh":     {Resolve: structField(func(obj *SolarForecast) interface{} { return obj.TotalKwh }), ReadsStruct: true},
+		"SolarHour.cellTemperature":  {Resolve: structField(func(obj *SolarHour) interface{} { return obj.CellTemperature }), ReadsStruct: true},
+		"SolarHour.energyKwh":        {Resolve: structField(func(obj *SolarHour) interface{} { return obj.EnergyKwh }), ReadsStruct: true},
+		"SolarHour.irradiance":       {Resolve: structField(func(obj *SolarHour) interface{} { return obj.Irradiance }), ReadsStruct: true},
+		"SolarHour.time":             {Resolve: structField(func(obj *SolarHour) interface{} { return obj.Time }), ReadsStruct: true},
+		"SolarSystem.azimuth":        {Resolve: structField(func(obj *SolarSystem) interface{} { return obj.Azimuth }), ReadsStruct: true},
+		"SolarSystem.capacityKw":     {Resolve: structField(func(obj *SolarSystem) interface{} { return obj.CapacityKw }), ReadsStruct: true},
+		"SolarSystem.losses":         {Resolve: structField(func(obj *SolarSystem) interface{} { return obj.Losses }), ReadsStruct: true},
+		"SolarSystem.tilt":           {Resolve: structField(func(obj *SolarSystem) interface{} { return obj.Tilt }), ReadsStruct: true},
+		"Units.cloud_cover":          {Resolve: structField(func(obj *Units) interface{} { return obj.CloudCover }), ReadsStruct: true},
+		"Units.precipitation_sum":    {Resolve: structField(func(obj *Units) interface{} { return obj.PrecipitationSum }), ReadsStruct: true},
+		"Units.temperature_2m":       {Resolve: structField(func(obj *Units) interface{} { return obj.Temperature2m }), ReadsStruct: true},
+		"Units.temperature_2m_max":   {Resolve: structField(func(obj *Units) interface{} { return obj.Temperature2mMax }), ReadsStruct: true},
+		"Units.temperature_2m_min":   {Resolve: structField(func(obj *Units) interface{} { return obj.Temperature2mMin }), ReadsStruct: true},
+		"Units.time":                 {Resolve: structField(func(obj *Units) interface{} { return obj.Time }), ReadsStruct: true},
+		"Units.uv_index":             {Resolve: structField(func(obj *Units) interface{} { return obj.UvIndex }), ReadsStruct: true},
+		"Units.wind_speed_10m_max":   {Resolve: structField(func(obj *Units) interface{} { return obj.WindSpeed10mMax }), ReadsStruct: true},
+		"Units.wind_speed_80m":       {Resolve: structField(func(obj *Units) interface{} { return obj.WindSpeed80m }), ReadsStruct: true},
+		"WeatherCondition.category":  {Resolve: structField(func(obj *WeatherCondition) interface{} { return obj.Category }), ReadsStruct: true},
+		"WeatherCondition.code":      {Resolve: structField(func(obj *WeatherCondition) interface{} { return obj.Code }), ReadsStruct: true},
+		"WeatherCondition.dayIcon":   {Resolve: structField(func(obj *WeatherCondition) interface{} { return obj.DayIcon }), ReadsStruct: true},
+		"WeatherCondition.description": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherCondition](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherCondition().Description(p.Context, obj, decodeWeatherConditionDescriptionArgs(p.Args)))
+			},
+		},
+		"WeatherCondition.nightIcon": {Resolve: structField(func(obj *WeatherCondition) interface{} { return obj.NightIcon }), ReadsStruct: true},
+		"WeatherCondition.severity":  {Resolve: structField(func(obj *WeatherCondition) interface{} { return obj.Severity }), ReadsStruct: true},
+		"WeatherInfo.cloudCoverage": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().CloudCoverage(p.Context, obj))
+			},
+		},
+		"WeatherInfo.condition": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Condition(p.Context, obj))
+			},
+		},
+		"WeatherInfo.daily": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Daily(p.Context, obj))
+			},
+		},
+		"WeatherInfo.dailyUnits": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().DailyUnits(p.Context, obj))
+			},
+		},
+		"WeatherInfo.days": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Days(p.Context, obj))
+			},
+		},
+		"WeatherInfo.degreeDays": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().DegreeDays(p.Context, obj, decodeWeatherInfoDegreeDaysArgs(p.Args)))
+			},
+		},
+		"WeatherInfo.fetchedAt": {Resolve: structField(func(obj *WeatherInfo) interface{} { return obj.FetchedAt }), ReadsStruct: true},
+		"WeatherInfo.hourly": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Hourly(p.Context, obj))
+			},
+		},
+		"WeatherInfo.hourlyUnits": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().HourlyUnits(p.Context, obj))
+			},
+		},
+		"WeatherInfo.hours": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Hours(p.Context, obj))
+			},
+		},
+		"WeatherInfo.id":           {Resolve: structField(func(obj *WeatherInfo) interface{} { return obj.ID }), ReadsStruct: true},
+		"WeatherInfo.latitude":     {Resolve: structField(func(obj *WeatherInfo) interface{} { return obj.Latitude }), ReadsStruct: true},
+		"WeatherInfo.locationName": {Resolve: structField(func(obj *WeatherInfo) interface{} { return obj.LocationName }), ReadsStruct: true},
+		"WeatherInfo.longitude":    {Resolve: structField(func(obj *WeatherInfo) interface{} { return obj.Longitude }), ReadsStruct: true},
+		"WeatherInfo.maxTemperature": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().MaxTemperature(p.Context, obj))
+			},
+		},
+		"WeatherInfo.minTemperature": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().MinTemperature(p.Context, obj))
+			},
+		},
+		"WeatherInfo.temperature": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Temperature(p.Context, obj))
+			},
+		},
+		"WeatherInfo.units": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Units(p.Context, obj))
+			},
+		},
+		"WeatherInfo.uvIndex": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().UvIndex(p.Context, obj))
+			},
+		},
+		"WeatherInfo.uvIndexMax": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().UvIndexMax(p.Context, obj))
+			},
+		},
+		"WeatherInfo.weatherCode": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WeatherCode(p.Context, obj))
+			},
+		},
+		"WeatherInfo.weather_code": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().Weather_code(p.Context, obj))
+			},
+		},
+		"WeatherInfo.windBeaufort": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WindBeaufort(p.Context, obj))
+			},
+		},
+		"WeatherInfo.windCompass": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WindCompass(p.Context, obj))
+			},
+		},
+		"WeatherInfo.windDirection": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WindDirection(p.Context, obj))
+			},
+		},
+		"WeatherInfo.windSpeed": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WindSpeed(p.Context, obj))
+			},
+		},
+		"WeatherInfo.windU": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WindU(p.Context, obj))
+			},
+		},
+		"WeatherInfo.windV": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WindV(p.Context, obj))
+			},
+		},
+		"WeatherInfo.wind_direction_10m": {
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				obj, ok := sourceOf[WeatherInfo](p.Source)
+				if !ok {
+					return nil, nil
+				}
+				return resolved(r.WeatherInfo().WindDirection10m(p.Context, obj))
+			},
+		},
+		"Webhook.createdAt":              {Resolve: structField(func(obj *Webhook) interface{} { return obj.CreatedAt }), ReadsStruct: true},
+		"Webhook.enabled":                {Resolve: structField(func(obj *Webhook) interface{} { return obj.Enabled }), ReadsStruct: true},
+		"Webhook.events":                 {Resolve: structField(func(obj *Webhook) interface{} { return obj.Events }), ReadsStruct: true},
+		"Webhook.id":                     {Resolve: structField(func(obj *Webhook) interface{} { return obj.ID }), ReadsStruct: true},
+		"Webhook.secret":                 {Resolve: structField(func(obj *Webhook) interface{} { return obj.Secret }), ReadsStruct: true},
+		"Webhook.url":                    {Resolve: structField(func(obj *Webhook) interface{} { return obj.URL }), ReadsStruct: true},
+		"WebhookDelivery.at":             {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.At }), ReadsStruct: true},
+		"WebhookDelivery.attempt":        {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.Attempt }), ReadsStruct: true},
+		"WebhookDelivery.durationMs":     {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.DurationMs }), ReadsStruct: true},
+		"WebhookDelivery.error":          {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.Error }), ReadsStruct: true},
+		"WebhookDelivery.eventID":        {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.EventID }), ReadsStruct: true},
+		"WebhookDelivery.eventType":      {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.EventType }), ReadsStruct: true},
+		"WebhookDelivery.id":             {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.ID }), ReadsStruct: true},
+		"WebhookDelivery.status":         {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.Status }), ReadsStruct: true},
+		"WebhookDelivery.statusCode":     {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.StatusCode }), ReadsStruct: true},
+		"WebhookDelivery.webhookID":      {Resolve: structField(func(obj *WebhookDelivery) interface{} { return obj.WebhookID }), ReadsStruct: true},
+		"WindDay.capacityFactor":         {Resolve: structField(func(obj *WindDay) interface{} { return obj.CapacityFactor }), ReadsStruct: true},
+		"WindDay.date":                   {Resolve: structField(func(obj *WindDay) interface{} { return obj.Date }), ReadsStruct: true},
+		"WindDay.energyKwh":              {Resolve: structField(func(obj *WindDay) interface{} { return obj.EnergyKwh }), ReadsStruct: true},
+		"WindHour.energyKwh":             {Resolve: structField(func(obj *WindHour) interface{} { return obj.EnergyKwh }), ReadsStruct: true},
+		"WindHour.hubWindSpeed":          {Resolve: structField(func(obj *WindHour) interface{} { return obj.HubWindSpeed }), ReadsStruct: true},
+		"WindHour.shearExponent":         {Resolve: structField(func(obj *WindHour) interface{} { return obj.ShearExponent }), ReadsStruct: true},
+		"WindHour.time":                  {Resolve: structField(func(obj *WindHour) interface{} { return obj.Time }), ReadsStruct: true},
+		"WindPowerForecast.daily":        {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.Daily }), ReadsStruct: true},
+		"WindPowerForecast.fetchedAt":    {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.FetchedAt }), ReadsStruct: true},
+		"WindPowerForecast.hourly":       {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.Hourly }), ReadsStruct: true},
+		"WindPowerForecast.id":           {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.ID }), ReadsStruct: true},
+		"WindPowerForecast.latitude":     {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.Latitude }), ReadsStruct: true},
+		"WindPowerForecast.locationName": {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.LocationName }), ReadsStruct: true},
+		"WindPowerForecast.longitude":    {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.Longitude }), ReadsStruct: true},
+		"WindPowerForecast.timezone":     {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.Timezone }), ReadsStruct: true},
+		"WindPowerForecast.totalKwh":     {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.TotalKwh }), ReadsStruct: true},
+		"WindPowerForecast.turbine":      {Resolve: structField(func(obj *WindPowerForecast) interface{} { return obj.Turbine }), ReadsStruct: true},
+		"WindTurbine.cutIn":              {Resolve: structField(func(obj *WindTurbine) interface{} { return obj.CutIn }), ReadsStruct: true},
+		"WindTurbine.cutOut":             {Resolve: structField(func(obj *WindTurbine) interface{} { return obj.CutOut }), ReadsStruct: true},
+		"WindTurbine.hubHeight":          {Resolve: structField(func(obj *WindTurbine) interface{} { return obj.HubHeight }), ReadsStruct: true},
+		"WindTurbine.powerCurve":         {Resolve: structField(func(obj *WindTurbine) interface{} { return obj.PowerCurve }), ReadsStruct: true},
+		"WindTurbine.ratedPowerKw":       {Resolve: structField(func(obj *WindTurbine) interface{} { return obj.RatedPowerKw }), ReadsStruct: true},
+		"WindTurbine.ratedSpeed":         {Resolve: structField(func(obj *WindTurbine) interface{} { return obj.RatedSpeed }), ReadsStruct: true},
+	}
+}
@@ -0,0 +1,919 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// resolvers implements the resolver interfaces generated from schema.graphql over the services of the App in the
+// context of each operation
+type resolvers struct{}
+
+func (resolvers) CurrentConditions() CurrentConditionsResolver { return currentConditionsResolver{} }
+func (resolvers) DailyData() DailyDataResolver                 { return dailyDataResolver{} }
+func (resolvers) DailyForecast() DailyForecastResolver         { return dailyForecastResolver{} }
+func (resolvers) DegreeDays() DegreeDaysResolver               { return degreeDaysResolver{} }
+func (resolvers) Forecast() ForecastResolver                   { return forecastResolver{} }
+func (resolvers) HistoricalWeather() HistoricalWeatherResolver { return historicalWeatherResolver{} }
+func (resolvers) Location() LocationResolver                   { return locationResolver{} }
+func (resolvers) RootMutation() RootMutationResolver           { return mutationResolver{} }
+func (resolvers) RootQuery() RootQueryResolver                 { return queryResolver{} }
+func (resolvers) RootSubscription() RootSubscriptionResolver   { return subscriptionResolver{} }
+func (resolvers) WeatherCondition() WeatherConditionResolver   { return weatherConditionResolver{} }
+func (resolvers) WeatherInfo() WeatherInfoResolver             { return weatherInfoResolver{} }
+
+// temperatureUnits, windSpeedUnits and precipitationUnits map the unit enums of the schema to unit options
+var (
+	temperatureUnits = map[TemperatureUnit]string{
+		TemperatureUnitCelsius:    Celsius,
+		TemperatureUnitFahrenheit: Fahrenheit,
+	}
+	windSpeedUnits = map[WindSpeedUnit]string{
+		WindSpeedUnitKmh: Kmh,
+		WindSpeedUnitMs:  Ms,
+		WindSpeedUnitMph: Mph,
+		WindSpeedUnitKn:  Knots,
+	}
+	precipitationUnits = map[PrecipitationUnit]string{
+		PrecipitationUnitMm:   Millimetre,
+		PrecipitationUnitInch: Inch,
+	}
+)
+
+// unitOptions reads the unit selection arguments
+func unitOptions(units UnitSystem, temperature *TemperatureUnit, windSpeed *WindSpeedUnit, precipitation *PrecipitationUnit) (UnitOptions, error) {
+	options := MetricUnits
+	if units == UnitSystemImperial {
+		options = ImperialUnits
+	}
+	if temperature != nil {
+		options.Temperature = temperatureUnits[*temperature]
+	}
+	if windSpeed != nil {
+		options.WindSpeed = windSpeedUnits[*windSpeed]
+	}
+	if precipitation != nil {
+		options.Precipitation = precipitationUnits[*precipitation]
+	}
+	return options, options.Validate()
+}
+
+// degreeDayOptions reads the arguments of degreeDays fields
+func degreeDayOptions(method string, base, coolingBase float64) DegreeDayOptions {
+	return DegreeDayOptions{Method: method, Base: base, CoolingBase: coolingBase}
+}
+
+// optional returns the value of an optional argument, the zero value when it is not given
+func optional[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+// readLocation reads the location an operation is about
+func readLocation(db Database, id string) (Location, error) {
+	var location Location
+	if err := db.d.Read("locations", id, &location); err != nil {
+		return Location{}, fmt.Errorf("location not found: %v", err)
+	}
+	return location, nil
+}
+
+type locationResolver struct{}
+
+func (locationResolver) Current(ctx context.Context, location *Location, args LocationCurrentArgs) (func() (*CurrentWeatherInfo, error), error) {
+	wl, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := unitOptions(args.Units, args.TemperatureUnit, args.WindSpeedUnit, args.PrecipitationUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Locations selected in the same operation are loaded together
+	load := wl.LoadCurrent(*location)
+	return func() (*CurrentWeatherInfo, error) {
+		weatherData, err := load()
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch weather data: %v", err)
+		}
+		if weatherData == nil {
+			return nil, nil
+		}
+		return units.ConvertCurrent(weatherData), nil
+	}, nil
+}
+
+func (locationResolver) Forecast(ctx context.Context, location *Location, args LocationForecastArgs) (func() (*WeatherForecastInfo, error), error) {
+	wl, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Days < 1 || args.Days > ForecastDays {
+		return nil, fmt.Errorf("forecast days must be between 1 and %d", ForecastDays)
+	}
+	units, err := unitOptions(args.Units, args.TemperatureUnit, args.WindSpeedUnit, args.PrecipitationUnit)
+	if err != nil {
+		return nil, err
+	}
+	metrics := args.Metrics
+	if len(metrics) == 0 {
+		metrics = DefaultForecastMetrics
+	}
+
+	load := wl.LoadForecast(*location, metrics)
+	return func() (*WeatherForecastInfo, error) {
+		weatherData, err := load()
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch weather data: %v", err)
+		}
+		return units.ConvertForecast(weatherData.FirstDays(args.Days)), nil
+	}, nil
+}
+
+type weatherConditionResolver struct{}
+
+func (weatherConditionResolver) Description(ctx context.Context, condition *WeatherCondition, args WeatherConditionDescriptionArgs) (*string, error) {
+	description := condition.Description(args.Language)
+	return &description, nil
+}
+
+type dailyDataResolver struct{}
+
+func (dailyDataResolver) WindCompass(ctx context.Context, daily *DailyData) ([]*string, error) {
+	labels := make([]*string, len(daily.WindDirectionAngle))
+	for i, direction := range daily.WindDirectionAngle {
+		if direction != nil {
+			label := CompassPoint(float64(*direction))
+			labels[i] = &label
+		}
+	}
+	return labels, nil
+}
+
+func (dailyDataResolver) WindBeaufort(ctx context.Context, daily *DailyData) ([]*int, error) {
+	forces := make([]*int, len(daily.WindSpeed10mMax))
+	for i, speed := range daily.WindSpeed10mMax {
+		if !math.IsNaN(speed) {
+			force := Beaufort(windSpeedInMs(speed, daily.windSpeedUnit))
+			forces[i] = &force
+		}
+	}
+	return forces, nil
+}
+
+func (dailyDataResolver) WindU(ctx context.Context, daily *DailyData) ([]*float64, error) {
+	u, _ := daily.windComponents()
+	return present(u), nil
+}
+
+func (dailyDataResolver) WindV(ctx context.Context, daily *DailyData) ([]*float64, error) {
+	_, v := daily.windComponents()
+	return present(v), nil
+}
+
+func (dailyDataResolver) Condition(ctx context.Context, daily *DailyData) ([]*WeatherCondition, error) {
+	conditions := make([]*WeatherCondition, len(daily.WeatherCode))
+	for i, code := range daily.WeatherCode {
+		if code != nil {
+			condition := LookupWeatherCode(*code)
+			conditions[i] = &condition
+		}
+	}
+	return conditions, nil
+}
+
+// present returns the values of a series, NaN for missing values becoming nil
+func present(values []float64) []*float64 {
+	pointers := make([]*float64, len(values))
+	for i := range values {
+		pointers[i] = floatAt(values, i)
+	}
+	return pointers
+}
+
+type dailyForecastResolver struct{}
+
+func (dailyForecastResolver) WindCompass(ctx context.Context, day *DailyForecast) (*string, error) {
+	if day.WindDirection == nil {
+		return nil, nil
+	}
+	compass := CompassPoint(float64(*day.WindDirection))
+	return &compass, nil
+}
+
+func (dailyForecastResolver) WindBeaufort(ctx context.Context, day *DailyForecast) (*int, error) {
+	if day.WindSpeedMax == nil {
+		return nil, nil
+	}
+	force := Beaufort(windSpeedInMs(*day.WindSpeedMax, day.windSpeedUnit))
+	return &force, nil
+}
+
+func (dailyForecastResolver) Condition(ctx context.Context, day *DailyForecast) (*WeatherCondition, error) {
+	if day.WeatherCode == nil {
+		return nil, nil
+	}
+	condition := LookupWeatherCode(*day.WeatherCode)
+	return &condition, nil
+}
+
+type degreeDaysResolver struct{}
+
+func (degreeDaysResolver) SeasonToDate(ctx context.Context, degreeDays *DegreeDays) (*DegreeDayAggregate, error) {
+	return degreeDays.SeasonToDate()
+}
+
+type currentConditionsResolver struct{}
+
+func (currentConditionsResolver) WindDirection(ctx context.Context, info *CurrentWeatherInfo) (*int, error) {
+	return &info.WindDirectionAngle, nil
+}
+
+func (currentConditionsResolver) WindCompass(ctx context.Context, info *CurrentWeatherInfo) (*string, error) {
+	compass := CompassPoint(float64(info.WindDirectionAngle))
+	return &compass, nil
+}
+
+func (currentConditionsResolver) WindBeaufort(ctx context.Context, info *CurrentWeatherInfo) (*int, error) {
+	force := Beaufort(windSpeedInMs(info.WindSpeed, info.Units.WindSpeed80m))
+	return &force, nil
+}
+
+func (currentConditionsResolver) WindU(ctx context.Context, info *CurrentWeatherInfo) (*float64, error) {
+	u, _ := WindComponents(info.WindSpeed, float64(info.WindDirectionAngle))
+	return &u, nil
+}
+
+func (currentConditionsResolver) WindV(ctx context.Context, info *CurrentWeatherInfo) (*float64, error) {
+	_, v := WindComponents(info.WindSpeed, float64(info.WindDirectionAngle))
+	return &v, nil
+}
+
+func (currentConditionsResolver) UvIndexMax(ctx context.Context, info *CurrentWeatherInfo) (*float64, error) {
+	if len(info.UvIndexMax) == 0 {
+		return nil, nil
+	}
+	return &info.UvIndexMax[0], nil
+}
+
+func (currentConditionsResolver) Condition(ctx context.Context, info *CurrentWeatherInfo) (*WeatherCondition, error) {
+	condition := LookupWeatherCode(info.WeatherCode)
+	return &condition, nil
+}
+
+type forecastResolver struct{}
+
+func (forecastResolver) Days(ctx context.Context, forecast *WeatherForecastInfo) ([]*DailyForecast, error) {
+	return forecast.Daily.Days(), nil
+}
+
+func (forecastResolver) Hours(ctx context.Context, forecast *WeatherForecastInfo) ([]*HourlyForecast, error) {
+	return forecast.Hourly.Hours(), nil
+}
+
+func (forecastResolver) DegreeDays(ctx context.Context, forecast *WeatherForecastInfo, args ForecastDegreeDaysArgs) (*DegreeDays, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Degree days are computed in °C whatever units the forecast is served in
+	options := degreeDayOptions(args.Method, args.Base, args.CoolingBase)
+	return app.DegreeDays.DegreeDaysForForecast(app.DB, app.Weather, app.Archive, MetricUnits.ConvertForecast(forecast), options)
+}
+
+type historicalWeatherResolver struct{}
+
+func (historicalWeatherResolver) DegreeDays(ctx context.Context, historical *HistoricalWeather, args HistoricalWeatherDegreeDaysArgs) (*DegreeDays, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	options := degreeDayOptions(args.Method, args.Base, args.CoolingBase)
+	return app.DegreeDays.DegreeDaysForHistorical(app.DB, app.Archive, historical, options)
+}
+
+// weatherInfoResolver resolves the fields of WeatherInfo from the current weather or the forecast it holds, the
+// fields of the other one resolving to null
+type weatherInfoResolver struct{}
+
+// fromCurrent resolves a WeatherInfo field from the current weather, and to null for forecasts
+func fromCurrent[T any](info *WeatherInfo, get func(current *CurrentWeatherInfo) T) (*T, error) {
+	if info.Current == nil {
+		return nil, nil
+	}
+	value := get(info.Current)
+	return &value, nil
+}
+
+// fromForecast resolves a WeatherInfo field from a forecast, and to null for the current weather
+func fromForecast[T any](info *WeatherInfo, get func(forecast *WeatherForecastInfo) T) (*T, error) {
+	if info.Forecast == nil {
+		return nil, nil
+	}
+	value := get(info.Forecast)
+	return &value, nil
+}
+
+func (weatherInfoResolver) Temperature(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) float64 { return current.Temperature })
+}
+
+func (weatherInfoResolver) MaxTemperature(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) float64 { return current.MaxTemperature })
+}
+
+func (weatherInfoResolver) MinTemperature(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) float64 { return current.MinTemperature })
+}
+
+func (weatherInfoResolver) CloudCoverage(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) float64 { return float64(current.CloudCoverage) })
+}
+
+func (weatherInfoResolver) WindSpeed(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) float64 { return current.WindSpeed })
+}
+
+func (weatherInfoResolver) WindDirection(ctx context.Context, info *WeatherInfo) (*int, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) int { return current.WindDirectionAngle })
+}
+
+func (weatherInfoResolver) WindDirection10m(ctx context.Context, info *WeatherInfo) (*int, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) int { return current.WindDirectionAngle })
+}
+
+func (weatherInfoResolver) UvIndex(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) float64 { return current.UvIndex })
+}
+
+func (weatherInfoResolver) WeatherCode(ctx context.Context, info *WeatherInfo) (*int, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) int { return current.WeatherCode })
+}
+
+func (weatherInfoResolver) Weather_code(ctx context.Context, info *WeatherInfo) (*int, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) int { return current.WeatherCode })
+}
+
+func (weatherInfoResolver) Units(ctx context.Context, info *WeatherInfo) (*Units, error) {
+	return fromCurrent(info, func(current *CurrentWeatherInfo) Units { return current.Units })
+}
+
+func (weatherInfoResolver) WindCompass(ctx context.Context, info *WeatherInfo) (*string, error) {
+	if info.Current == nil {
+		return nil, nil
+	}
+	return currentConditionsResolver{}.WindCompass(ctx, info.Current)
+}
+
+func (weatherInfoResolver) WindBeaufort(ctx context.Context, info *WeatherInfo) (*int, error) {
+	if info.Current == nil {
+		return nil, nil
+	}
+	return currentConditionsResolver{}.WindBeaufort(ctx, info.Current)
+}
+
+func (weatherInfoResolver) WindU(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	if info.Current == nil {
+		return nil, nil
+	}
+	return currentConditionsResolver{}.WindU(ctx, info.Current)
+}
+
+func (weatherInfoResolver) WindV(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	if info.Current == nil {
+		return nil, nil
+	}
+	return currentConditionsResolver{}.WindV(ctx, info.Current)
+}
+
+func (weatherInfoResolver) UvIndexMax(ctx context.Context, info *WeatherInfo) (*float64, error) {
+	if info.Current == nil {
+		return nil, nil
+	}
+	return currentConditionsResolver{}.UvIndexMax(ctx, info.Current)
+}
+
+func (weatherInfoResolver) Condition(ctx context.Context, info *WeatherInfo) (*WeatherCondition, error) {
+	if info.Current == nil {
+		return nil, nil
+	}
+	return currentConditionsResolver{}.Condition(ctx, info.Current)
+}
+
+func (weatherInfoResolver) Daily(ctx context.Context, info *WeatherInfo) (*DailyData, error) {
+	return fromForecast(info, func(forecast *WeatherForecastInfo) DailyData { return forecast.Daily })
+}
+
+func (weatherInfoResolver) Hourly(ctx context.Context, info *WeatherInfo) (*HourlyData, error) {
+	return fromForecast(info, func(forecast *WeatherForecastInfo) HourlyData { return forecast.Hourly })
+}
+
+func (weatherInfoResolver) DailyUnits(ctx context.Context, info *WeatherInfo) (*Units, error) {
+	return fromForecast(info, func(forecast *WeatherForecastInfo) Units { return forecast.DailyUnits })
+}
+
+func (weatherInfoResolver) HourlyUnits(ctx context.Context, info *WeatherInfo) (*Units, error) {
+	return fromForecast(info, func(forecast *WeatherForecastInfo) Units { return forecast.HourlyUnits })
+}
+
+func (weatherInfoResolver) Days(ctx context.Context, info *WeatherInfo) ([]*DailyForecast, error) {
+	if info.Forecast == nil {
+		return nil, nil
+	}
+	return forecastResolver{}.Days(ctx, info.Forecast)
+}
+
+func (weatherInfoResolver) Hours(ctx context.Context, info *WeatherInfo) ([]*HourlyForecast, error) {
+	if info.Forecast == nil {
+		return nil, nil
+	}
+	return forecastResolver{}.Hours(ctx, info.Forecast)
+}
+
+func (weatherInfoResolver) DegreeDays(ctx context.Context, info *WeatherInfo, args WeatherInfoDegreeDaysArgs) (*DegreeDays, error) {
+	if info.Forecast == nil {
+		return nil, nil
+	}
+	return forecastResolver{}.DegreeDays(ctx, info.Forecast, ForecastDegreeDaysArgs(args))
+}
+
+type queryResolver struct{}
+
+func (queryResolver) Locations(ctx context.Context) ([]*Location, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := app.Locations.GetLocations(app.DB)
+	if err != nil {
+		return nil, err
+	}
+	served := make([]*Location, len(locations))
+	for i := range locations {
+		served[i] = &locations[i]
+	}
+	return served, nil
+}
+
+func (queryResolver) Location(ctx context.Context, args RootQueryLocationArgs) (*Location, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := readLocation(app.DB, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (queryResolver) WeatherForecast(ctx context.Context, args RootQueryWeatherForecastArgs) (*WeatherInfo, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := unitOptions(args.Units, args.TemperatureUnit, args.WindSpeedUnit, args.PrecipitationUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := readLocation(app.DB, optional(args.LocationID))
+	if err != nil {
+		return nil, err
+	}
+
+	weatherData, err := app.Weather.LatestWeatherForecast(app.DB, location, args.Metrics)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch weather data: %v", err)
+	}
+	return forecastWeatherInfo(units.ConvertForecast(weatherData)), nil
+}
+
+func (queryResolver) ForecastHistory(ctx context.Context, args RootQueryForecastHistoryArgs) ([]*WeatherInfo, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := app.Weather.GetForecastHistory(app.DB, args.LocationID, optional(args.IssuedAfter), optional(args.IssuedBefore))
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch forecast history: %v", err)
+	}
+	served := make([]*WeatherInfo, len(history))
+	for i, forecast := range history {
+		served[i] = forecastWeatherInfo(forecast)
+	}
+	return served, nil
+}
+
+func (queryResolver) HistoricalWeather(ctx context.Context, args RootQueryHistoricalWeatherArgs) (*HistoricalWeather, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(dateLayout, args.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date: %v", err)
+	}
+	end, err := time.Parse(dateLayout, args.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date: %v", err)
+	}
+
+	location, err := readLocation(app.DB, args.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	historical, err := app.Archive.FetchHistoricalWeather(app.DB, location, start, end, args.Metrics, args.Resolution)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch historical weather: %v", err)
+	}
+	return historical, nil
+}
+
+func (queryResolver) ForecastAccuracy(ctx context.Context, args RootQueryForecastAccuracyArgs) ([]*ForecastAccuracy, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accuracies, err := app.Verification.GetForecastAccuracy(app.DB, optional(args.LocationID))
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch forecast accuracy: %v", err)
+	}
+
+	filtered := []*ForecastAccuracy{}
+	for _, accuracy := range accuracies {
+		if args.Metric != nil && *args.Metric != "" && accuracy.Metric != *args.Metric {
+			continue
+		}
+		if args.LeadDays != nil && accuracy.LeadDays != *args.LeadDays {
+			continue
+		}
+		filtered = append(filtered, accuracy)
+	}
+	return filtered, nil
+}
+
+func (queryResolver) SolarForecast(ctx context.Context, args RootQuerySolarForecastArgs) (*SolarForecast, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := readLocation(app.DB, args.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := app.Solar.SolarForecast(app.Weather, location, solarSystem(args.System), args.Days)
+	if err != nil {
+		return nil, fmt.Errorf("could not estimate solar production: %v", err)
+	}
+	return forecast, nil
+}
+
+func (queryResolver) WindPowerForecast(ctx context.Context, args RootQueryWindPowerForecastArgs) (*WindPowerForecast, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := readLocation(app.DB, args.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := app.Wind.WindPowerForecast(app.Weather, location, windTurbine(args.Turbine), args.Days)
+	if err != nil {
+		return nil, fmt.Errorf("could not estimate wind production: %v", err)
+	}
+	return forecast, nil
+}
+
+func (queryResolver) HeatDemandForecast(ctx context.Context, args RootQueryHeatDemandForecastArgs) (*HeatDemandForecast, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	building := Building{
+		HeatLossCoefficient: args.Building.HeatLossCoefficient,
+		Setpoint:            args.Building.Setpoint,
+		FlowTemperature:     args.Building.FlowTemperature,
+	}
+	for _, point := range args.Building.CopCurve {
+		building.COPCurve = append(building.COPCurve, COPPoint{
+			OutdoorTemperature: point.OutdoorTemperature,
+			COP:                point.Cop,
+		})
+	}
+
+	location, err := readLocation(app.DB, args.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := app.HeatDemand.HeatDemandForecast(app.DB, app.Weather, location, building)
+	if err != nil {
+		return nil, fmt.Errorf("could not forecast heat demand: %v", err)
+	}
+	return forecast, nil
+}
+
+func (queryResolver) GreenWindows(ctx context.Context, args RootQueryGreenWindowsArgs) ([]*GreenWindow, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := GreenWindowRequest{
+		DurationHours: args.DurationHours,
+		HorizonHours:  args.Horizon,
+		Limit:         args.Limit,
+		Load: LoadProfile{
+			FlexibleLoadKw:   args.Load.FlexibleLoadKw,
+			BaseLoadKw:       args.Load.BaseLoadKw,
+			HourlyBaseLoadKw: args.Load.HourlyBaseLoadKw,
+		},
+	}
+	if args.Solar != nil {
+		system := solarSystem(*args.Solar)
+		request.Solar = &system
+	}
+	if args.Turbine != nil {
+		turbine := windTurbine(*args.Turbine)
+		request.Turbine = &turbine
+	}
+
+	location, err := readLocation(app.DB, args.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := app.GreenWindows.GreenWindows(app.Weather, app.Solar, app.Wind, location, request)
+	if err != nil {
+		return nil, fmt.Errorf("could not find green windows: %v", err)
+	}
+	return windows, nil
+}
+
+func (queryResolver) AlertRules(ctx context.Context) ([]*AlertRule, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Alerts.GetAlertRules(app.DB)
+}
+
+func (queryResolver) Alerts(ctx context.Context, args RootQueryAlertsArgs) ([]*Alert, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := AlertFilter{
+		LocationID:     optional(args.LocationID),
+		RuleID:         optional(args.RuleID),
+		Unacknowledged: args.Unacknowledged,
+		Limit:          args.Limit,
+		Since:          optional(args.Since),
+	}
+
+	alerts, err := app.Alerts.GetAlerts(app.DB, filter)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch alerts: %v", err)
+	}
+	return alerts, nil
+}
+
+func (queryResolver) Webhooks(ctx context.Context) ([]*Webhook, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Webhooks.GetWebhooks()
+}
+
+func (queryResolver) WebhookDeliveries(ctx context.Context, args RootQueryWebhookDeliveriesArgs) ([]*WebhookDelivery, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Webhooks.GetWebhookDeliveries(optional(args.WebhookID), args.Limit)
+}
+
+func (queryResolver) WeatherForLocations(ctx context.Context, args RootQueryWeatherForLocationsArgs) ([]*WeatherInfo, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := unitOptions(args.Units, args.TemperatureUnit, args.WindSpeedUnit, args.PrecipitationUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	weatherData, err := app.Weather.LatestWeatherForLocations(app.DB, app.Locations)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch weather data: %v", err)
+	}
+	served := make([]*WeatherInfo, len(weatherData))
+	for i, info := range weatherData {
+		served[i] = currentWeatherInfo(units.ConvertCurrent(info))
+	}
+	return served, nil
+}
+
+// solarSystem reads a SolarSystemInput
+func solarSystem(input SolarSystemInput) SolarSystem {
+	return SolarSystem{
+		CapacityKw: input.CapacityKw,
+		Tilt:       input.Tilt,
+		Azimuth:    input.Azimuth,
+		Losses:     input.Losses,
+	}
+}
+
+// windTurbine reads a WindTurbineInput
+func windTurbine(input WindTurbineInput) WindTurbine {
+	turbine := WindTurbine{
+		RatedPowerKw: input.RatedPowerKw,
+		HubHeight:    input.HubHeight,
+		CutIn:        input.CutIn,
+		RatedSpeed:   input.RatedSpeed,
+		CutOut:       input.CutOut,
+	}
+	for _, point := range input.PowerCurve {
+		turbine.PowerCurve = append(turbine.PowerCurve, PowerCurvePoint{Speed: point.Speed, PowerKw: point.PowerKw})
+	}
+	return turbine
+}
+
+// alertRule reads an AlertRuleInput
+func alertRule(input AlertRuleInput) AlertRule {
+	return AlertRule{
+		Name:            input.Name,
+		LocationIDs:     input.LocationIDs,
+		Metric:          input.Metric,
+		Operator:        input.Operator,
+		Threshold:       input.Threshold,
+		HorizonHours:    input.HorizonHours,
+		CooldownMinutes: input.CooldownMinutes,
+		Channels:        input.Channels,
+		WebhookURL:      optional(input.WebhookURL),
+		Email:           optional(input.Email),
+		Enabled:         input.Enabled,
+	}
+}
+
+type mutationResolver struct{}
+
+func (mutationResolver) AddLocation(ctx context.Context, args RootMutationAddLocationArgs) (*Location, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	location := Location{
+		Name:      optional(args.Name),
+		Latitude:  args.Latitude,
+		Longitude: args.Longitude,
+	}
+	if err := app.Locations.AddLocation(app.DB, location); err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (mutationResolver) DeleteLocation(ctx context.Context, args RootMutationDeleteLocationArgs) (*Location, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nil, app.Locations.DeleteLocation(app.DB, args.ID)
+}
+
+func (mutationResolver) UpdateLocation(ctx context.Context, args RootMutationUpdateLocationArgs) (*Location, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Locations.UpdateLocation(app.DB, args.ID, args.Name)
+}
+
+func (mutationResolver) CreateWebhook(ctx context.Context, args RootMutationCreateWebhookArgs) (*Webhook, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := Webhook{
+		URL:    args.URL,
+		Events: args.Events,
+		Secret: optional(args.Secret),
+	}
+	return app.Webhooks.CreateWebhook(webhook)
+}
+
+func (mutationResolver) SetWebhookEnabled(ctx context.Context, args RootMutationSetWebhookEnabledArgs) (*Webhook, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Webhooks.SetWebhookEnabled(args.ID, args.Enabled)
+}
+
+func (mutationResolver) DeleteWebhook(ctx context.Context, args RootMutationDeleteWebhookArgs) (*Webhook, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Webhooks.DeleteWebhook(args.ID)
+}
+
+func (mutationResolver) TestWebhook(ctx context.Context, args RootMutationTestWebhookArgs) (*WebhookDelivery, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Webhooks.TestWebhook(ctx, args.ID)
+}
+
+func (mutationResolver) CreateAlertRule(ctx context.Context, args RootMutationCreateAlertRuleArgs) (*AlertRule, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Alerts.SaveAlertRule(app.DB, alertRule(args.Input))
+}
+
+func (mutationResolver) UpdateAlertRule(ctx context.Context, args RootMutationUpdateAlertRuleArgs) (*AlertRule, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := alertRule(args.Input)
+	rule.ID = args.ID
+	return app.Alerts.SaveAlertRule(app.DB, rule)
+}
+
+func (mutationResolver) DeleteAlertRule(ctx context.Context, args RootMutationDeleteAlertRuleArgs) (*AlertRule, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Alerts.DeleteAlertRule(app.DB, app.Locations, args.ID)
+}
+
+func (mutationResolver) AcknowledgeAlert(ctx context.Context, args RootMutationAcknowledgeAlertArgs) (*Alert, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Alerts.AcknowledgeAlert(app.DB, args.ID)
+}
+
+type subscriptionResolver struct{}
+
+func (subscriptionResolver) WeatherUpdates(ctx context.Context, args RootSubscriptionWeatherUpdatesArgs) (<-chan *WeatherInfo, error) {
+	app, err := AppFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The subscription channel is closed once the context is done, which ends this goroutine too
+	updates := app.Subscriptions.Subscribe(ctx, args.LocationIDs)
+	events := make(chan *WeatherInfo)
+	go func() {
+		defer close(events)
+		for update := range updates {
+			info, ok := update.(*CurrentWeatherInfo)
+			if !ok {
+				continue
+			}
+			select {
+			case events <- currentWeatherInfo(info):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,207 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// fixtureCurrent is current weather with every value set
+func fixtureCurrent() *CurrentWeatherInfo {
+	return &CurrentWeatherInfo{
+		ID:                 "52.5200_13.4050",
+		LocationName:       "Berlin",
+		Latitude:           "52.5200",
+		Longitude:          "13.4050",
+		Temperature:        17.8,
+		MaxTemperature:     21.2,
+		MinTemperature:     11.4,
+		CloudCoverage:      40,
+		WindSpeed:          14.4,
+		UvIndex:            3.1,
+		UvIndexMax:         []float64{5.2},
+		WeatherCode:        3,
+		WindDirectionAngle: 290,
+		Units:              Units{Temperature2m: "°C", CloudCover: "%", WindSpeed80m: "km/h", UvIndex: ""},
+		FetchedAt:          time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// fixtureForecast is a two-day forecast with every metric set
+func fixtureForecast() *WeatherForecastInfo {
+	units := Units{
+		Time:             "iso8601",
+		Temperature2m:    "°C",
+		Temperature2mMax: "°C",
+		Temperature2mMin: "°C",
+		CloudCover:       "%",
+		WindSpeed80m:     "km/h",
+		WindSpeed10mMax:  "km/h",
+		UvIndex:          "",
+		PrecipitationSum: "mm",
+	}
+	return &WeatherForecastInfo{
+		ID:           "52.5200_13.4050",
+		LocationName: "Berlin",
+		Latitude:     "52.5200",
+		Longitude:    "13.4050",
+		DailyUnits:   units,
+		Daily: DailyData{
+			Time:               []string{"2024-05-01", "2024-05-02"},
+			Temperature2mMax:   []float64{21.2, 19.5},
+			Temperature2mMin:   []float64{11.4, 10.1},
+			WindSpeed10mMax:    []float64{24.5, 31.0},
+			WeatherCode:        []*int{pointer(3), pointer(61)},
+			WindDirectionAngle: []*int{pointer(290), pointer(250)},
+			UvIndexMax:         []float64{5.2, 4.0},
+			PrecipitationSum:   []float64{0, 3.4},
+		},
+		HourlyUnits: units,
+		Hourly: HourlyData{
+			Time:          []string{"2024-05-01T00:00", "2024-05-01T01:00"},
+			Temperature2m: []float64{12.1, 11.8},
+			CloudCover:    []*int{pointer(20), pointer(35)},
+			WindSpeed80m:  []float64{18.2, 20.4},
+			UvIndex:       []float64{0, 0},
+		},
+		FetchedAt:        time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		UtcOffsetSeconds: 7200,
+	}
+}
+
+// pointer returns a pointer to a value
+func pointer[T any](value T) *T {
+	return &value
+}
+
+// fixtureResolvers serves the fixtures from the root fields and the nested fields of Location, leaving every
+// other field to the resolvers the API is served with
+type fixtureResolvers struct{ resolvers }
+
+func (fixtureResolvers) RootQuery() RootQueryResolver { return fixtureQuery{} }
+func (fixtureResolvers) Location() LocationResolver   { return fixtureLocation{} }
+
+type fixtureQuery struct{ queryResolver }
+
+func (fixtureQuery) WeatherForecast(ctx context.Context, args RootQueryWeatherForecastArgs) (*WeatherInfo, error) {
+	return forecastWeatherInfo(fixtureForecast()), nil
+}
+
+func (fixtureQuery) WeatherForLocations(ctx context.Context, args RootQueryWeatherForLocationsArgs) ([]*WeatherInfo, error) {
+	return []*WeatherInfo{currentWeatherInfo(fixtureCurrent())}, nil
+}
+
+func (fixtureQuery) Location(ctx context.Context, args RootQueryLocationArgs) (*Location, error) {
+	return &Location{ID: "52.5200_13.4050", Name: "Berlin", Latitude: "52.5200", Longitude: "13.4050"}, nil
+}
+
+type fixtureLocation struct{}
+
+func (fixtureLocation) Current(ctx context.Context, location *Location, args LocationCurrentArgs) (func() (*CurrentWeatherInfo, error), error) {
+	return func() (*CurrentWeatherInfo, error) { return fixtureCurrent(), nil }, nil
+}
+
+func (fixtureLocation) Forecast(ctx context.Context, location *Location, args LocationForecastArgs) (func() (*WeatherForecastInfo, error), error) {
+	return func() (*WeatherForecastInfo, error) { return fixtureForecast(), nil }, nil
+}
+
+// unresolvedFields are left out of the test as they need the services of an App
+var unresolvedFields = map[string]bool{
+	"degreeDays": true,
+}
+
+// selection selects every field of an object type, recursing into nested objects
+func selection(object *graphql.Object, depth int) string {
+	var fields []string
+	for _, name := range sortedKeys(object.Fields()) {
+		if unresolvedFields[name] {
+			continue
+		}
+		t := object.Fields()[name].Type
+		for {
+			if list, ok := t.(*graphql.List); ok {
+				t = list.OfType
+				continue
+			}
+			if nonNull, ok := t.(*graphql.NonNull); ok {
+				t = nonNull.OfType
+				continue
+			}
+			break
+		}
+		if nested, ok := t.(*graphql.Object); ok {
+			if depth == 0 {
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s { %s }", name, selection(nested, depth-1)))
+			continue
+		}
+		fields = append(fields, name)
+	}
+	return strings.Join(fields, " ")
+}
+
+// resolvedFields lists the fields of an object type, but those the test leaves out
+func resolvedFields(object *graphql.Object) []string {
+	var fields []string
+	for _, name := range sortedKeys(object.Fields()) {
+		if !unresolvedFields[name] {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+func TestSchemaResolvesEveryWeatherField(t *testing.T) {
+	schema, err := buildSchema(SDL, schemaResolvers(fixtureResolvers{}))
+	if err != nil {
+		t.Fatalf("could not build schema: %v", err)
+	}
+	weatherInfo := schema.Type("WeatherInfo").(*graphql.Object)
+	currentConditions := schema.Type("CurrentConditions").(*graphql.Object)
+	forecast := schema.Type("Forecast").(*graphql.Object)
+
+	query := fmt.Sprintf(`{
+		WeatherForecast(locationID: "52.5200_13.4050") { %[1]s }
+		weatherForLocations { %[1]s }
+		location(id: "52.5200_13.4050") {
+			current { %[2]s }
+			forecast { %[3]s }
+		}
+	}`, selection(weatherInfo, 2), selection(currentConditions, 2), selection(forecast, 2))
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: context.Background()})
+	if result.HasErrors() {
+		t.Fatalf("query failed: %v", result.Errors)
+	}
+	data := result.Data.(map[string]interface{})
+	location := data["location"].(map[string]interface{})
+	forecastInfo := data["WeatherForecast"].(map[string]interface{})
+	currentInfo := data["weatherForLocations"].([]interface{})[0].(map[string]interface{})
+
+	for _, field := range resolvedFields(currentConditions) {
+		if location["current"].(map[string]interface{})[field] == nil {
+			t.Errorf("CurrentConditions.%s is null", field)
+		}
+	}
+	for _, field := range resolvedFields(forecast) {
+		if location["forecast"].(map[string]interface{})[field] == nil {
+			t.Errorf("Forecast.%s is null", field)
+		}
+	}
+	// A WeatherInfo holds either current weather or a forecast, so each of its fields is set by one of them
+	for _, field := range resolvedFields(weatherInfo) {
+		if currentInfo[field] == nil && forecastInfo[field] == nil {
+			t.Errorf("WeatherInfo.%s is null for both current weather and forecasts", field)
+		}
+	}
+	for _, field := range []string{"id", "locationName", "latitude", "longitude", "fetchedAt"} {
+		if currentInfo[field] == nil || forecastInfo[field] == nil {
+			t.Errorf("WeatherInfo.%s is null", field)
+		}
+	}
+}
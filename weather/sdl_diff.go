@@ -0,0 +1,181 @@
+package weather
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// sdlType is the part of a type definition that matters to clients
+type sdlType struct {
+	kind string
+	// fields maps object fields and input fields to their type
+	fields map[string]string
+	// args maps "field.argument" to the argument type
+	args map[string]string
+	// optional holds input fields and arguments that have a default value
+	optional map[string]bool
+	values   map[string]bool
+}
+
+// BreakingChanges lists the changes from a previous SDL to the current one that can break existing clients:
+// removed types, fields, arguments and enum values, changed types, and new required arguments or input fields
+func BreakingChanges(previous, current string) ([]string, error) {
+	before, err := parseSDL(previous)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse previous schema: %v", err)
+	}
+	after, err := parseSDL(current)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse current schema: %v", err)
+	}
+
+	var changes []string
+	for _, name := range sortedKeys(before) {
+		old, now := before[name], after[name]
+		if now == nil {
+			changes = append(changes, fmt.Sprintf("type %s was removed", name))
+			continue
+		}
+		if old.kind != now.kind {
+			changes = append(changes, fmt.Sprintf("type %s changed from %s to %s", name, old.kind, now.kind))
+			continue
+		}
+
+		for _, value := range sortedKeys(old.values) {
+			if !now.values[value] {
+				changes = append(changes, fmt.Sprintf("enum value %s.%s was removed", name, value))
+			}
+		}
+
+		input := old.kind == "input"
+		for _, field := range sortedKeys(old.fields) {
+			newType, ok := now.fields[field]
+			switch {
+			case !ok:
+				changes = append(changes, fmt.Sprintf("field %s.%s was removed", name, field))
+			case !compatibleType(old.fields[field], newType, input):
+				changes = append(changes, fmt.Sprintf("field %s.%s changed type from %s to %s", name, field, old.fields[field], newType))
+			}
+		}
+		for _, arg := range sortedKeys(old.args) {
+			field := strings.SplitN(arg, ".", 2)[0]
+			if _, ok := now.fields[field]; !ok {
+				continue
+			}
+			newType, ok := now.args[arg]
+			switch {
+			case !ok:
+				changes = append(changes, fmt.Sprintf("argument %s.%s was removed", name, arg))
+			case !compatibleType(old.args[arg], newType, true):
+				changes = append(changes, fmt.Sprintf("argument %s.%s changed type from %s to %s", name, arg, old.args[arg], newType))
+			}
+		}
+
+		// New required inputs break clients that don't send them
+		added := map[string]string{}
+		if input {
+			for field, fieldType := range now.fields {
+				if _, ok := old.fields[field]; !ok {
+					added[field] = fieldType
+				}
+			}
+		}
+		for arg, argType := range now.args {
+			if _, ok := old.args[arg]; !ok {
+				if _, existed := old.fields[strings.SplitN(arg, ".", 2)[0]]; existed {
+					added[arg] = argType
+				}
+			}
+		}
+		for _, input := range sortedKeys(added) {
+			if strings.HasSuffix(added[input], "!") && !now.optional[input] {
+				changes = append(changes, fmt.Sprintf("required input %s.%s was added", name, input))
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// compatibleType reports whether a type can change from old to new without breaking clients. Output fields may become
+// non-null, and inputs may become nullable.
+func compatibleType(old, new string, input bool) bool {
+	if old == new {
+		return true
+	}
+	if input {
+		return old == new+"!"
+	}
+	return old+"!" == new
+}
+
+// parseSDL reads the type definitions of an SDL document
+func parseSDL(sdl string) (map[string]*sdlType, error) {
+	document, err := parser.Parse(parser.ParseParams{Source: sdl})
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[string]*sdlType{}
+	for _, definition := range document.Definitions {
+		t := &sdlType{fields: map[string]string{}, args: map[string]string{}, optional: map[string]bool{}, values: map[string]bool{}}
+		var name string
+
+		switch definition := definition.(type) {
+		case *ast.ScalarDefinition:
+			name, t.kind = definition.Name.Value, "scalar"
+		case *ast.EnumDefinition:
+			name, t.kind = definition.Name.Value, "enum"
+			for _, value := range definition.Values {
+				t.values[value.Name.Value] = true
+			}
+		case *ast.InputObjectDefinition:
+			name, t.kind = definition.Name.Value, "input"
+			for _, field := range definition.Fields {
+				t.fields[field.Name.Value] = typeString(field.Type)
+				t.optional[field.Name.Value] = field.DefaultValue != nil
+			}
+		case *ast.ObjectDefinition:
+			name, t.kind = definition.Name.Value, "type"
+			for _, field := range definition.Fields {
+				t.fields[field.Name.Value] = typeString(field.Type)
+				for _, arg := range field.Arguments {
+					key := field.Name.Value + "." + arg.Name.Value
+					t.args[key] = typeString(arg.Type)
+					t.optional[key] = arg.DefaultValue != nil
+				}
+			}
+		default:
+			continue
+		}
+		types[name] = t
+	}
+	return types, nil
+}
+
+// typeString prints a type reference the way it is written in SDL
+func typeString(t ast.Type) string {
+	switch t := t.(type) {
+	case *ast.Named:
+		return t.Name.Value
+	case *ast.List:
+		return "[" + typeString(t.Type) + "]"
+	case *ast.NonNull:
+		return typeString(t.Type) + "!"
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of a map sorted
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+package weather
+
+import (
+	"reflect"
+	"testing"
+)
+
+// previousSDL is the schema the changes of TestBreakingChanges are made to
+const previousSDL = `
+schema { query: Query }
+
+enum Unit { METRIC IMPERIAL }
+
+input RangeInput {
+  from: Int
+  to: Int!
+}
+
+type Query {
+  temperature(unit: Unit = METRIC): Float
+  wind(range: RangeInput): Float!
+  location(id: String!): String
+}
+`
+
+func TestBreakingChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		want    []string
+	}{
+		{
+			name:    "unchanged",
+			current: previousSDL,
+		},
+		{
+			name: "removed field",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int to: Int! }
+type Query {
+  temperature(unit: Unit = METRIC): Float
+  wind(range: RangeInput): Float!
+}`,
+			want: []string{"field Query.location was removed"},
+		},
+		{
+			name: "removed enum value",
+			current: `
+schema { query: Query }
+enum Unit { METRIC }
+input RangeInput { from: Int to: Int! }
+type Query {
+  temperature(unit: Unit = METRIC): Float
+  wind(range: RangeInput): Float!
+  location(id: String!): String
+}`,
+			want: []string{"enum value Unit.IMPERIAL was removed"},
+		},
+		{
+			name: "output field becomes nullable",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int to: Int! }
+type Query {
+  temperature(unit: Unit = METRIC): Float
+  wind(range: RangeInput): Float
+  location(id: String!): String
+}`,
+			want: []string{"field Query.wind changed type from Float! to Float"},
+		},
+		{
+			name: "output field becomes non-null",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int to: Int! }
+type Query {
+  temperature(unit: Unit = METRIC): Float!
+  wind(range: RangeInput): Float!
+  location(id: String!): String
+}`,
+		},
+		{
+			name: "input becomes non-null",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int! to: Int! }
+type Query {
+  temperature(unit: Unit = METRIC): Float
+  wind(range: RangeInput): Float!
+  location(id: String!): String
+}`,
+			want: []string{"field RangeInput.from changed type from Int to Int!"},
+		},
+		{
+			name: "input becomes nullable",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int to: Int }
+type Query {
+  temperature(unit: Unit = METRIC): Float
+  wind(range: RangeInput): Float!
+  location(id: String): String
+}`,
+		},
+		{
+			name: "new required argument",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int to: Int! }
+type Query {
+  temperature(unit: Unit = METRIC, at: String!): Float
+  wind(range: RangeInput): Float!
+  location(id: String!): String
+}`,
+			want: []string{"required input Query.temperature.at was added"},
+		},
+		{
+			name: "new non-null argument with a default",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int to: Int! }
+type Query {
+  temperature(unit: Unit = METRIC, days: Int! = 7): Float
+  wind(range: RangeInput): Float!
+  location(id: String!): String
+}`,
+		},
+		{
+			name: "new required input field",
+			current: `
+schema { query: Query }
+enum Unit { METRIC IMPERIAL }
+input RangeInput { from: Int to: Int! step: Int! }
+type Query {
+  temperature(unit: Unit = METRIC): Float
+  wind(range: RangeInput): Float!
+  location(id: String!): String
+}`,
+			want: []string{"required input RangeInput.step was added"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := BreakingChanges(previousSDL, test.current)
+			if err != nil {
+				t.Fatalf("BreakingChanges failed: %v", err)
+			}
+			if !reflect.DeepEqual(changes, test.want) {
+				t.Errorf("BreakingChanges = %q, want %q", changes, test.want)
+			}
+		})
+	}
+}
+
+func TestBreakingChangesRejectsInvalidSDL(t *testing.T) {
+	if _, err := BreakingChanges(previousSDL, "type Query {"); err == nil {
+		t.Error("BreakingChanges accepted an invalid schema")
+	}
+}
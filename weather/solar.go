@@ -0,0 +1,99 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Photovoltaic model constants
+const (
+	// groundAlbedo is the share of irradiance reflected by the ground onto the panels
+	groundAlbedo = 0.2
+	// nominalOperatingCellTemperature is the cell temperature at 800 W/m² and 20 °C ambient (NOCT)
+	nominalOperatingCellTemperature = 45.0
+	// powerTemperatureCoefficient is the relative power change per degree of cell temperature above 25 °C
+	powerTemperatureCoefficient = -0.004
+	// minSolarCosine keeps the beam irradiance from blowing up when the sun is at the horizon
+	minSolarCosine = 0.065
+)
+
+// solarMetrics are the hourly metrics the photovoltaic model needs
+var solarMetrics = []string{"shortwave_radiation", "direct_radiation", "diffuse_radiation", "temperature_2m"}
+
+// validate checks a photovoltaic system is physically meaningful
+func (system SolarSystem) validate() error {
+	switch {
+	case system.CapacityKw <= 0:
+		return fmt.Errorf("capacity must be positive")
+	case system.Tilt < 0 || system.Tilt > 90:
+		return fmt.Errorf("tilt must be between 0 and 90 degrees")
+	case system.Azimuth < 0 || system.Azimuth > 360:
+		return fmt.Errorf("azimuth must be between 0 and 360 degrees")
+	case system.Losses < 0 || system.Losses >= 100:
+		return fmt.Errorf("losses must be between 0 and 100 percent")
+	}
+	return nil
+}
+
+// solarPosition computes the zenith and compass azimuth of the sun, in degrees, using the NOAA approximations
+func solarPosition(t time.Time, latitude, longitude float64) (zenith, azimuth float64) {
+	t = t.UTC()
+	hours := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	gamma := 2 * math.Pi / 365 * (float64(t.YearDay()-1) + (hours-12)/24)
+
+	equationOfTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	declination := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	trueSolarMinutes := hours*60 + equationOfTime + 4*longitude
+	hourAngle := radians(trueSolarMinutes/4 - 180)
+	phi := radians(latitude)
+
+	cosZenith := math.Sin(phi)*math.Sin(declination) + math.Cos(phi)*math.Cos(declination)*math.Cos(hourAngle)
+	zenith = degrees(math.Acos(math.Max(-1, math.Min(1, cosZenith))))
+
+	azimuth = degrees(math.Atan2(math.Sin(hourAngle), math.Cos(hourAngle)*math.Sin(phi)-math.Tan(declination)*math.Cos(phi))) + 180
+	return zenith, math.Mod(azimuth, 360)
+}
+
+// planeOfArrayIrradiance transposes horizontal irradiance onto tilted panels with the isotropic sky model
+func (system SolarSystem) planeOfArrayIrradiance(zenith, azimuth, global, direct, diffuse float64) float64 {
+	if global <= 0 {
+		return 0
+	}
+
+	tilt := radians(system.Tilt)
+	cosZenith := math.Cos(radians(zenith))
+
+	var beam float64
+	if cosZenith > 0 {
+		cosIncidence := cosZenith*math.Cos(tilt) + math.Sin(radians(zenith))*math.Sin(tilt)*math.Cos(radians(azimuth-system.Azimuth))
+		beam = direct / math.Max(cosZenith, minSolarCosine) * math.Max(0, cosIncidence)
+	}
+	sky := diffuse * (1 + math.Cos(tilt)) / 2
+	ground := global * groundAlbedo * (1 - math.Cos(tilt)) / 2
+
+	return beam + sky + ground
+}
+
+// power computes the output of the system in kW for a plane-of-array irradiance and ambient temperature,
+// derated by the cell temperature and the system losses. It also returns the cell temperature.
+func (system SolarSystem) power(irradiance, ambient float64) (float64, float64) {
+	cellTemperature := ambient + irradiance/800*(nominalOperatingCellTemperature-20)
+	derating := 1 + powerTemperatureCoefficient*(cellTemperature-25)
+	power := system.CapacityKw * irradiance / 1000 * derating * (1 - system.Losses/100)
+	return math.Max(0, power), cellTemperature
+}
+
+// radians converts degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// degrees converts radians to degrees
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
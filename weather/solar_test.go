@@ -0,0 +1,59 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSolarPosition(t *testing.T) {
+	tests := []struct {
+		name        string
+		at          time.Time
+		latitude    float64
+		longitude   float64
+		wantZenith  float64
+		wantAzimuth float64
+	}{
+		// Reference example of the NREL solar position algorithm, which the NOAA approximations follow within 0.3°
+		{"Golden, Colorado", time.Date(2003, 10, 17, 19, 30, 30, 0, time.UTC), 39.742476, -105.1786, 50.11162, 194.34024},
+		// Solar noon at the June solstice, the zenith being the latitude less the 23.44° declination
+		{"Berlin at the solstice", time.Date(2024, 6, 21, 11, 8, 0, 0, time.UTC), 52.52, 13.405, 29.08, 180},
+	}
+	for _, test := range tests {
+		zenith, azimuth := solarPosition(test.at, test.latitude, test.longitude)
+		if math.Abs(zenith-test.wantZenith) > 0.3 || math.Abs(azimuth-test.wantAzimuth) > 0.3 {
+			t.Errorf("%s: solarPosition = %.3f, %.3f, want %.3f, %.3f", test.name, zenith, azimuth, test.wantZenith, test.wantAzimuth)
+		}
+	}
+
+	// The sun is overhead at the equator at noon on the equinox
+	if zenith, _ := solarPosition(time.Date(2024, 3, 20, 12, 7, 0, 0, time.UTC), 0, 0); zenith > 0.5 {
+		t.Errorf("zenith at the equator on the equinox = %.3f, want about 0", zenith)
+	}
+}
+
+func TestPlaneOfArrayIrradiance(t *testing.T) {
+	flat := SolarSystem{CapacityKw: 5, Tilt: 0, Azimuth: 180}
+	tests := []struct {
+		name   string
+		system SolarSystem
+		zenith float64
+		global float64
+		direct float64
+		want   float64
+	}{
+		// Flat panels receive the global horizontal irradiance
+		{"flat", flat, 40, 600, 450, 600},
+		{"flat, low sun", flat, 80, 150, 50, 150},
+		{"night", SolarSystem{CapacityKw: 5, Tilt: 35, Azimuth: 180}, 100, 0, 0, 0},
+		// Vertical panels facing the sun: the beam at incidence 30°, half the sky and half the ground reflection
+		{"vertical facing the sun", SolarSystem{CapacityKw: 5, Tilt: 90, Azimuth: 180}, 60, 600, 450, 450/0.5*math.Sqrt(3)/2 + 150/2 + 600*groundAlbedo/2},
+	}
+	for _, test := range tests {
+		got := test.system.planeOfArrayIrradiance(test.zenith, 180, test.global, test.direct, test.global-test.direct)
+		if math.Abs(got-test.want) > 1e-6 {
+			t.Errorf("%s: planeOfArrayIrradiance = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
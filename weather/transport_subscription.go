@@ -0,0 +1,298 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"golang.org/x/net/websocket"
+)
+
+// graphqlTransportWS is the WebSocket sub-protocol spoken by graphql-ws clients
+const graphqlTransportWS = "graphql-transport-ws"
+
+// SubscriptionServer serves GraphQL operations as streams, over WebSocket (graphql-transport-ws) or Server-Sent Events
+type SubscriptionServer struct {
+	Schema *graphql.Schema
+	// Context decorates the request context before operations are executed, e.g. to attach controllers
+	Context func(ctx context.Context) context.Context
+	// Done closes every open subscription when it is closed, e.g. when the server shuts down
+	Done <-chan struct{}
+	// InitTimeout is how long a WebSocket client has to send connection_init
+	InitTimeout time.Duration
+	// KeepAlive is the interval between SSE keep-alive comments
+	KeepAlive time.Duration
+}
+
+// OperationRequest is the payload of a GraphQL operation sent over a stream
+type OperationRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// wsMessage is a graphql-transport-ws protocol message
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// execute runs a request and streams its results; queries and mutations yield a single result
+func (s *SubscriptionServer) execute(ctx context.Context, req OperationRequest) chan *graphql.Result {
+	if s.Context != nil {
+		ctx = s.Context(ctx)
+	}
+	params := graphql.Params{
+		Schema:         *s.Schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	}
+
+	if isSubscription(req.Query, req.OperationName) {
+		return graphql.Subscribe(params)
+	}
+
+	results := make(chan *graphql.Result, 1)
+	results <- graphql.Do(params)
+	close(results)
+	return results
+}
+
+// cancelOnDone cancels a connection's context once the server is done
+func (s *SubscriptionServer) cancelOnDone(ctx context.Context, cancel context.CancelFunc) {
+	if s.Done == nil {
+		return
+	}
+	go func() {
+		select {
+		case <-s.Done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// isSubscription reports whether the selected operation of a query is a subscription
+func isSubscription(query, operationName string) bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		// Let the executor report the syntax error
+		return true
+	}
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (operation.Name != nil && operation.Name.Value == operationName) {
+			return operation.Operation == ast.OperationTypeSubscription
+		}
+	}
+	return false
+}
+
+// ServeWebSocket handles a graphql-transport-ws connection
+func (s *SubscriptionServer) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	server := websocket.Server{
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			for _, protocol := range config.Protocol {
+				if protocol == graphqlTransportWS {
+					config.Protocol = []string{graphqlTransportWS}
+					return nil
+				}
+			}
+			return fmt.Errorf("unsupported websocket sub-protocol %v", config.Protocol)
+		},
+		Handler: s.handleWebSocket,
+	}
+	server.ServeHTTP(w, r)
+}
+
+// handleWebSocket runs the graphql-transport-ws protocol over an accepted connection
+func (s *SubscriptionServer) handleWebSocket(ws *websocket.Conn) {
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	s.cancelOnDone(ctx, cancel)
+	defer cancel()
+
+	var writeMu sync.Mutex
+	send := func(msg wsMessage) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if err := websocket.JSON.Send(ws, msg); err != nil {
+			cancel()
+		}
+	}
+
+	var opsMu sync.Mutex
+	operations := map[string]context.CancelFunc{}
+
+	initTimeout := s.InitTimeout
+	if initTimeout <= 0 {
+		initTimeout = 10 * time.Second
+	}
+	ws.SetReadDeadline(time.Now().Add(initTimeout))
+
+	acknowledged := false
+	for {
+		var msg wsMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			if acknowledged {
+				// Too many initialisation requests
+				return
+			}
+			acknowledged = true
+			ws.SetReadDeadline(time.Time{})
+			send(wsMessage{Type: "connection_ack"})
+
+		case "ping":
+			send(wsMessage{Type: "pong"})
+
+		case "pong":
+
+		case "subscribe":
+			if !acknowledged {
+				return
+			}
+			var req OperationRequest
+			if err := json.Unmarshal(msg.Payload, &req); err != nil {
+				return
+			}
+
+			opsMu.Lock()
+			if _, exists := operations[msg.ID]; exists {
+				// Subscriber for this ID already exists
+				opsMu.Unlock()
+				return
+			}
+			opCtx, opCancel := context.WithCancel(ctx)
+			operations[msg.ID] = opCancel
+			opsMu.Unlock()
+
+			go func(id string) {
+				defer func() {
+					opsMu.Lock()
+					_, active := operations[id]
+					delete(operations, id)
+					opsMu.Unlock()
+					opCancel()
+					// Only tell the client about completion when it didn't ask for it
+					if active && ctx.Err() == nil {
+						send(wsMessage{ID: id, Type: "complete"})
+					}
+				}()
+
+				for result := range s.execute(opCtx, req) {
+					if opCtx.Err() != nil {
+						// Keep draining so the executor can shut down
+						continue
+					}
+					payload, err := json.Marshal(result)
+					if err != nil {
+						continue
+					}
+					send(wsMessage{ID: id, Type: "next", Payload: payload})
+				}
+			}(msg.ID)
+
+		case "complete":
+			opsMu.Lock()
+			if opCancel, ok := operations[msg.ID]; ok {
+				delete(operations, msg.ID)
+				opCancel()
+			}
+			opsMu.Unlock()
+
+		default:
+			return
+		}
+	}
+}
+
+// ServeSSE streams the results of an operation as Server-Sent Events.
+// The operation is read from the query string on GET and from the JSON body on POST.
+func (s *SubscriptionServer) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	var req OperationRequest
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	} else {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				http.Error(w, fmt.Sprintf("invalid variables: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", sse.ContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := s.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = 15 * time.Second
+	}
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	s.cancelOnDone(ctx, cancel)
+
+	results := s.execute(ctx, req)
+	for {
+		select {
+		case result, more := <-results:
+			if !more {
+				sse.Encode(w, sse.Event{Event: "complete", Data: ""})
+				flusher.Flush()
+				return
+			}
+			if ctx.Err() != nil {
+				continue
+			}
+			if err := sse.Encode(w, sse.Event{Event: "next", Data: result}); err != nil {
+				cancel()
+				continue
+			}
+			flusher.Flush()
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ":\n\n"); err != nil {
+				cancel()
+				continue
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package weather
+
+import "fmt"
+
+// Unit names, as accepted by Open-Meteo
+const (
+	Celsius    = "celsius"
+	Fahrenheit = "fahrenheit"
+	Kmh        = "kmh"
+	Ms         = "ms"
+	Mph        = "mph"
+	Knots      = "kn"
+	Millimetre = "mm"
+	Inch       = "inch"
+)
+
+// unitLabels are the labels Open-Meteo gives each unit in its responses
+var unitLabels = map[string]string{
+	Celsius:    "°C",
+	Fahrenheit: "°F",
+	Kmh:        "km/h",
+	Ms:         "m/s",
+	Mph:        "mp/h",
+	Knots:      "kn",
+	Millimetre: "mm",
+	Inch:       "inch",
+}
+
+// UnitOptions selects the units weather values are served in. Values are always fetched and stored in Open-Meteo
+// defaults and converted when served, so snapshots, history and alerts stay in a single unit system.
+type UnitOptions struct {
+	Temperature   string
+	WindSpeed     string
+	Precipitation string
+}
+
+// Unit system presets
+var (
+	MetricUnits   = UnitOptions{Temperature: Celsius, WindSpeed: Kmh, Precipitation: Millimetre}
+	ImperialUnits = UnitOptions{Temperature: Fahrenheit, WindSpeed: Mph, Precipitation: Inch}
+)
+
+// Validate checks every unit is known
+func (options UnitOptions) Validate() error {
+	switch {
+	case options.Temperature != Celsius && options.Temperature != Fahrenheit:
+		return fmt.Errorf("temperature unit must be %s or %s", Celsius, Fahrenheit)
+	case options.WindSpeed != Kmh && options.WindSpeed != Ms && options.WindSpeed != Mph && options.WindSpeed != Knots:
+		return fmt.Errorf("wind speed unit must be %s, %s, %s or %s", Kmh, Ms, Mph, Knots)
+	case options.Precipitation != Millimetre && options.Precipitation != Inch:
+		return fmt.Errorf("precipitation unit must be %s or %s", Millimetre, Inch)
+	}
+	return nil
+}
+
+// convertTemperature converts a temperature from a unit label to a unit
+func convertTemperature(value float64, from string, to string) float64 {
+	switch {
+	case from == unitLabels[Fahrenheit] && to == Celsius:
+		return round((value-32)*5/9, 1)
+	case from != unitLabels[Fahrenheit] && to == Fahrenheit:
+		return round(value*9/5+32, 1)
+	}
+	return value
+}
+
+// convertWindSpeed converts a wind speed from a unit label to a unit
+func convertWindSpeed(value float64, from string, to string) float64 {
+	if from == unitLabels[to] || (from == "" && to == Kmh) {
+		return value
+	}
+	speed := windSpeedInMs(value, from)
+	switch to {
+	case Kmh:
+		return round(speed*3.6, 1)
+	case Mph:
+		return round(speed/0.44704, 1)
+	case Knots:
+		return round(speed/0.514444, 1)
+	}
+	return round(speed, 1)
+}
+
+// convertPrecipitation converts a precipitation amount from a unit label to a unit
+func convertPrecipitation(value float64, from string, to string) float64 {
+	switch {
+	case from == unitLabels[Inch] && to == Millimetre:
+		return round(value*25.4, 1)
+	case from != unitLabels[Inch] && to == Inch:
+		return round(value/25.4, 3)
+	}
+	return value
+}
+
+// convertSeries converts a series of values with one of the convert functions, returning a new slice
+func convertSeries(values []float64, from string, to string, convert func(float64, string, string) float64) []float64 {
+	if values == nil {
+		return nil
+	}
+	converted := make([]float64, len(values))
+	for i, value := range values {
+		converted[i] = convert(value, from, to)
+	}
+	return converted
+}
+
+// relabel returns the label of a unit for metrics present in a response, leaving absent ones empty
+func relabel(label string, unit string) string {
+	if label == "" {
+		return ""
+	}
+	return unitLabels[unit]
+}
+
+// ConvertForecast returns a copy of a forecast in the selected units
+func (options UnitOptions) ConvertForecast(info *WeatherForecastInfo) *WeatherForecastInfo {
+	converted := *info
+
+	daily, units := info.Daily, info.DailyUnits
+	converted.Daily.Temperature2mMax = convertSeries(daily.Temperature2mMax, units.Temperature2mMax, options.Temperature, convertTemperature)
+	converted.Daily.Temperature2mMin = convertSeries(daily.Temperature2mMin, units.Temperature2mMin, options.Temperature, convertTemperature)
+	converted.Daily.WindSpeed10mMax = convertSeries(daily.WindSpeed10mMax, units.WindSpeed10mMax, options.WindSpeed, convertWindSpeed)
+	converted.Daily.PrecipitationSum = convertSeries(daily.PrecipitationSum, units.PrecipitationSum, options.Precipitation, convertPrecipitation)
+	converted.DailyUnits.Temperature2mMax = relabel(units.Temperature2mMax, options.Temperature)
+	converted.DailyUnits.Temperature2mMin = relabel(units.Temperature2mMin, options.Temperature)
+	converted.DailyUnits.WindSpeed10mMax = relabel(units.WindSpeed10mMax, options.WindSpeed)
+	converted.DailyUnits.PrecipitationSum = relabel(units.PrecipitationSum, options.Precipitation)
+	converted.Daily.windSpeedUnit = converted.DailyUnits.WindSpeed10mMax
+
+	hourly, units := info.Hourly, info.HourlyUnits
+	converted.Hourly.Temperature2m = convertSeries(hourly.Temperature2m, units.Temperature2m, options.Temperature, convertTemperature)
+	converted.Hourly.WindSpeed80m = convertSeries(hourly.WindSpeed80m, units.WindSpeed80m, options.WindSpeed, convertWindSpeed)
+	converted.HourlyUnits.Temperature2m = relabel(units.Temperature2m, options.Temperature)
+	converted.HourlyUnits.WindSpeed80m = relabel(units.WindSpeed80m, options.WindSpeed)
+
+	return &converted
+}
+
+// ConvertCurrent returns a copy of the current weather in the selected units
+func (options UnitOptions) ConvertCurrent(info *CurrentWeatherInfo) *CurrentWeatherInfo {
+	converted := *info
+	units := info.Units
+
+	converted.Temperature = convertTemperature(info.Temperature, units.Temperature2m, options.Temperature)
+	converted.MaxTemperature = convertTemperature(info.MaxTemperature, units.Temperature2m, options.Temperature)
+	converted.MinTemperature = convertTemperature(info.MinTemperature, units.Temperature2m, options.Temperature)
+	converted.WindSpeed = convertWindSpeed(info.WindSpeed, units.WindSpeed80m, options.WindSpeed)
+	converted.Units.Temperature2m = unitLabels[options.Temperature]
+	converted.Units.WindSpeed80m = unitLabels[options.WindSpeed]
+
+	return &converted
+}
@@ -0,0 +1,96 @@
+package weather
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Upstream records the outcome of the calls made to an upstream provider
+type Upstream struct {
+	Name string
+	// Client sends the requests, http.DefaultClient when nil
+	Client *http.Client
+
+	mu          sync.Mutex
+	calls       int
+	failures    int
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+}
+
+// Upstream providers the weather is fetched from
+var (
+	ForecastUpstream = &Upstream{Name: "open-meteo-forecast"}
+	ArchiveUpstream  = &Upstream{Name: "open-meteo-archive"}
+)
+
+// Get sends a GET request to the provider, timing it and counting transport errors, rate limiting and server errors
+// as failures. Errors leave out the query of the URL, which may hold an API key.
+func (u *Upstream) Get(query string) (*http.Response, error) {
+	start := time.Now()
+	client := u.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(query)
+	upstreamDuration.Observe(time.Since(start).Seconds(), u.Name)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	upstreamRequests.Inc(u.Name, code)
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL, _, _ = strings.Cut(urlErr.URL, "?")
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.calls++
+	switch {
+	case err != nil:
+		u.fail(err.Error())
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		u.fail(resp.Status)
+	default:
+		u.lastSuccess = time.Now()
+	}
+	return resp, err
+}
+
+// fail records a failed call
+func (u *Upstream) fail(reason string) {
+	u.failures++
+	u.lastFailure = time.Now()
+	u.lastError = reason
+}
+
+// Status reports the provider healthy until its last call failed
+func (u *Upstream) Status() DependencyStatus {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	status := DependencyStatus{
+		Name:    u.Name,
+		Healthy: !u.lastFailure.After(u.lastSuccess),
+		Details: map[string]interface{}{
+			"calls":    u.calls,
+			"failures": u.failures,
+		},
+	}
+	if !u.lastSuccess.IsZero() {
+		status.Details["lastSuccess"] = u.lastSuccess.UTC()
+	}
+	if !u.lastFailure.IsZero() {
+		status.Details["lastFailure"] = u.lastFailure.UTC()
+		status.Details["lastError"] = u.lastError
+	}
+	return status
+}
@@ -0,0 +1,57 @@
+package weather
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpstreamStatus(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	u := &Upstream{Name: "test", Client: server.Client()}
+	get := func() {
+		resp, err := u.Get(server.URL + "?apikey=secret")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	get()
+	if s := u.Status(); !s.Healthy || s.Details["calls"] != 1 {
+		t.Errorf("status after a success = %+v, want healthy after 1 call", s)
+	}
+	status = http.StatusServiceUnavailable
+	get()
+	if s := u.Status(); s.Healthy || s.Details["failures"] != 1 || s.Details["lastError"] != "503 Service Unavailable" {
+		t.Errorf("status after a server error = %+v, want unhealthy after 1 failure", s)
+	}
+	status = http.StatusOK
+	get()
+	if s := u.Status(); !s.Healthy {
+		t.Errorf("status after a recovery = %+v, want healthy", s)
+	}
+}
+
+func TestUpstreamErrorHidesQuery(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	u := &Upstream{Name: "test", Client: server.Client()}
+	_, err := u.Get(server.URL + "?apikey=secret")
+	if err == nil {
+		t.Fatal("request to a closed server succeeded")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error %q holds the query", err)
+	}
+	if s := u.Status(); s.Healthy {
+		t.Errorf("status after a transport error = %+v, want unhealthy", s)
+	}
+}
@@ -0,0 +1,78 @@
+package weather
+
+// Weather condition severities
+const (
+	SeverityNone     = 0
+	SeverityMinor    = 1
+	SeverityModerate = 2
+	SeveritySevere   = 3
+)
+
+// defaultLanguage is the language of descriptions when the requested one isn't available
+const defaultLanguage = "en"
+
+// WeatherCondition describes a WMO weather interpretation code as used by Open-Meteo
+type WeatherCondition struct {
+	Code     int
+	Category string
+	// Descriptions are human descriptions keyed by ISO 639-1 language code
+	Descriptions map[string]string
+	DayIcon      string
+	NightIcon    string
+	Severity     int
+}
+
+// Description returns the description of the condition in a language, falling back to English
+func (condition WeatherCondition) Description(language string) string {
+	if description, ok := condition.Descriptions[language]; ok {
+		return description
+	}
+	return condition.Descriptions[defaultLanguage]
+}
+
+// weatherConditions is the WMO weather code catalogue
+var weatherConditions = map[int]WeatherCondition{
+	0:  {0, "clear", map[string]string{"en": "Clear sky", "de": "Klarer Himmel"}, "clear-day", "clear-night", SeverityNone},
+	1:  {1, "clear", map[string]string{"en": "Mainly clear", "de": "Überwiegend klar"}, "mostly-clear-day", "mostly-clear-night", SeverityNone},
+	2:  {2, "cloudy", map[string]string{"en": "Partly cloudy", "de": "Teilweise bewölkt"}, "partly-cloudy-day", "partly-cloudy-night", SeverityNone},
+	3:  {3, "cloudy", map[string]string{"en": "Overcast", "de": "Bedeckt"}, "overcast", "overcast", SeverityNone},
+	45: {45, "fog", map[string]string{"en": "Fog", "de": "Nebel"}, "fog-day", "fog-night", SeverityMinor},
+	48: {48, "fog", map[string]string{"en": "Depositing rime fog", "de": "Nebel mit Reifbildung"}, "fog-day", "fog-night", SeverityModerate},
+	51: {51, "drizzle", map[string]string{"en": "Light drizzle", "de": "Leichter Nieselregen"}, "drizzle", "drizzle", SeverityNone},
+	53: {53, "drizzle", map[string]string{"en": "Moderate drizzle", "de": "Mäßiger Nieselregen"}, "drizzle", "drizzle", SeverityMinor},
+	55: {55, "drizzle", map[string]string{"en": "Dense drizzle", "de": "Starker Nieselregen"}, "drizzle", "drizzle", SeverityMinor},
+	56: {56, "freezing", map[string]string{"en": "Light freezing drizzle", "de": "Leichter gefrierender Nieselregen"}, "sleet", "sleet", SeverityModerate},
+	57: {57, "freezing", map[string]string{"en": "Dense freezing drizzle", "de": "Starker gefrierender Nieselregen"}, "sleet", "sleet", SeveritySevere},
+	61: {61, "rain", map[string]string{"en": "Slight rain", "de": "Leichter Regen"}, "rain", "rain", SeverityNone},
+	63: {63, "rain", map[string]string{"en": "Moderate rain", "de": "Mäßiger Regen"}, "rain", "rain", SeverityMinor},
+	65: {65, "rain", map[string]string{"en": "Heavy rain", "de": "Starker Regen"}, "heavy-rain", "heavy-rain", SeverityModerate},
+	66: {66, "freezing", map[string]string{"en": "Light freezing rain", "de": "Leichter gefrierender Regen"}, "sleet", "sleet", SeverityModerate},
+	67: {67, "freezing", map[string]string{"en": "Heavy freezing rain", "de": "Starker gefrierender Regen"}, "sleet", "sleet", SeveritySevere},
+	71: {71, "snow", map[string]string{"en": "Slight snowfall", "de": "Leichter Schneefall"}, "snow", "snow", SeverityMinor},
+	73: {73, "snow", map[string]string{"en": "Moderate snowfall", "de": "Mäßiger Schneefall"}, "snow", "snow", SeverityModerate},
+	75: {75, "snow", map[string]string{"en": "Heavy snowfall", "de": "Starker Schneefall"}, "heavy-snow", "heavy-snow", SeveritySevere},
+	77: {77, "snow", map[string]string{"en": "Snow grains", "de": "Schneegriesel"}, "snow", "snow", SeverityMinor},
+	80: {80, "showers", map[string]string{"en": "Slight rain showers", "de": "Leichte Regenschauer"}, "showers-day", "showers-night", SeverityNone},
+	81: {81, "showers", map[string]string{"en": "Moderate rain showers", "de": "Mäßige Regenschauer"}, "showers-day", "showers-night", SeverityMinor},
+	82: {82, "showers", map[string]string{"en": "Violent rain showers", "de": "Heftige Regenschauer"}, "heavy-rain", "heavy-rain", SeveritySevere},
+	85: {85, "snow", map[string]string{"en": "Slight snow showers", "de": "Leichte Schneeschauer"}, "snow-showers-day", "snow-showers-night", SeverityMinor},
+	86: {86, "snow", map[string]string{"en": "Heavy snow showers", "de": "Starke Schneeschauer"}, "heavy-snow", "heavy-snow", SeveritySevere},
+	95: {95, "thunderstorm", map[string]string{"en": "Thunderstorm", "de": "Gewitter"}, "thunderstorm", "thunderstorm", SeverityModerate},
+	96: {96, "thunderstorm", map[string]string{"en": "Thunderstorm with slight hail", "de": "Gewitter mit leichtem Hagel"}, "thunderstorm-hail", "thunderstorm-hail", SeveritySevere},
+	99: {99, "thunderstorm", map[string]string{"en": "Thunderstorm with heavy hail", "de": "Gewitter mit starkem Hagel"}, "thunderstorm-hail", "thunderstorm-hail", SeveritySevere},
+}
+
+// LookupWeatherCode returns the condition of a WMO weather code, or an unknown condition for codes outside the catalogue
+func LookupWeatherCode(code int) WeatherCondition {
+	if condition, ok := weatherConditions[code]; ok {
+		return condition
+	}
+	return WeatherCondition{
+		Code:         code,
+		Category:     "unknown",
+		Descriptions: map[string]string{"en": "Unknown", "de": "Unbekannt"},
+		DayIcon:      "unknown",
+		NightIcon:    "unknown",
+		Severity:     SeverityNone,
+	}
+}
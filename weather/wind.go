@@ -0,0 +1,121 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// defaultShearExponent is the wind shear exponent of open terrain, used when measured speeds can't give one
+const defaultShearExponent = 1.0 / 7
+
+// windHeights are the heights in metres of the forecasted wind speeds, with their Open-Meteo metrics
+var windHeights = []struct {
+	height float64
+	metric string
+}{
+	{10, "wind_speed_10m"},
+	{80, "wind_speed_80m"},
+	{120, "wind_speed_120m"},
+}
+
+// validate checks a wind turbine is physically meaningful and sorts its power curve
+func (turbine *WindTurbine) validate() error {
+	switch {
+	case turbine.RatedPowerKw <= 0:
+		return fmt.Errorf("rated power must be positive")
+	case turbine.HubHeight <= 0:
+		return fmt.Errorf("hub height must be positive")
+	case turbine.CutIn < 0 || turbine.RatedSpeed <= turbine.CutIn || turbine.CutOut <= turbine.RatedSpeed:
+		return fmt.Errorf("speeds must satisfy 0 <= cut-in < rated < cut-out")
+	}
+	for _, point := range turbine.PowerCurve {
+		if point.Speed < 0 || point.PowerKw < 0 {
+			return fmt.Errorf("power curve points must not be negative")
+		}
+	}
+	sort.Slice(turbine.PowerCurve, func(i, j int) bool {
+		return turbine.PowerCurve[i].Speed < turbine.PowerCurve[j].Speed
+	})
+	return nil
+}
+
+// power returns the output in kW of the turbine at a hub-height wind speed in m/s
+func (turbine *WindTurbine) power(speed float64) float64 {
+	if speed < turbine.CutIn || speed >= turbine.CutOut {
+		return 0
+	}
+
+	if len(turbine.PowerCurve) == 0 {
+		if speed >= turbine.RatedSpeed {
+			return turbine.RatedPowerKw
+		}
+		cutIn, rated := math.Pow(turbine.CutIn, 3), math.Pow(turbine.RatedSpeed, 3)
+		return turbine.RatedPowerKw * (math.Pow(speed, 3) - cutIn) / (rated - cutIn)
+	}
+
+	// Interpolate the tabulated curve linearly, holding its last value until cut-out
+	curve := turbine.PowerCurve
+	if speed <= curve[0].Speed {
+		return curve[0].PowerKw * speed / math.Max(curve[0].Speed, 1e-9)
+	}
+	for i := 1; i < len(curve); i++ {
+		if speed <= curve[i].Speed {
+			ratio := (speed - curve[i-1].Speed) / (curve[i].Speed - curve[i-1].Speed)
+			return math.Min(turbine.RatedPowerKw, curve[i-1].PowerKw+ratio*(curve[i].PowerKw-curve[i-1].PowerKw))
+		}
+	}
+	return math.Min(turbine.RatedPowerKw, curve[len(curve)-1].PowerKw)
+}
+
+// hubWindSpeed extrapolates the wind speed at hub height with a power-law profile fitted on the two forecasted
+// heights around the hub. When neither has a speed, the nearest height that has one is extrapolated with the default
+// shear exponent. It returns the speed and the shear exponent used, 0 for both without any speed.
+func hubWindSpeed(hubHeight float64, speeds map[float64]float64) (float64, float64) {
+	lower, upper := windHeights[0].height, windHeights[1].height
+	for i := 1; i < len(windHeights)-1; i++ {
+		if hubHeight > windHeights[i].height {
+			lower, upper = windHeights[i].height, windHeights[i+1].height
+		}
+	}
+
+	lowerSpeed, hasLower := speeds[lower]
+	upperSpeed, hasUpper := speeds[upper]
+	if !hasLower && !hasUpper {
+		nearest := math.Inf(1)
+		for _, height := range windHeights {
+			if _, ok := speeds[height.height]; ok && math.Abs(hubHeight-height.height) < math.Abs(hubHeight-nearest) {
+				nearest = height.height
+			}
+		}
+		if math.IsInf(nearest, 1) {
+			return 0, 0
+		}
+		return speeds[nearest] * math.Pow(hubHeight/nearest, defaultShearExponent), defaultShearExponent
+	}
+
+	exponent := defaultShearExponent
+	if hasLower && hasUpper && lowerSpeed > 0 && upperSpeed > 0 {
+		exponent = math.Max(0, math.Min(0.6, math.Log(upperSpeed/lowerSpeed)/math.Log(upper/lower)))
+	}
+
+	reference, referenceSpeed := lower, lowerSpeed
+	if !hasLower || (hasUpper && math.Abs(hubHeight-upper) < math.Abs(hubHeight-lower)) {
+		reference, referenceSpeed = upper, upperSpeed
+	}
+
+	return referenceSpeed * math.Pow(hubHeight/reference, exponent), exponent
+}
+
+// metresPerSecond converts a wind speed from an Open-Meteo unit to m/s
+func metresPerSecond(speed float64, unit string) float64 {
+	switch unit {
+	case "km/h":
+		return speed / 3.6
+	case "mp/h":
+		return speed * 0.44704
+	case "kn":
+		return speed * 0.514444
+	}
+	return speed
+}
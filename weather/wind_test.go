@@ -0,0 +1,60 @@
+package weather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHubWindSpeed(t *testing.T) {
+	tests := []struct {
+		name         string
+		hubHeight    float64
+		speeds       map[float64]float64
+		wantSpeed    float64
+		wantExponent float64
+	}{
+		{
+			name:         "fitted between the heights around the hub",
+			hubHeight:    100,
+			speeds:       map[float64]float64{10: 4, 80: 8, 120: 9},
+			wantSpeed:    9 * math.Pow(100.0/120, math.Log(9.0/8)/math.Log(120.0/80)),
+			wantExponent: math.Log(9.0/8) / math.Log(120.0/80),
+		},
+		{
+			name:         "one height around the hub",
+			hubHeight:    100,
+			speeds:       map[float64]float64{80: 8},
+			wantSpeed:    8 * math.Pow(100.0/80, defaultShearExponent),
+			wantExponent: defaultShearExponent,
+		},
+		{
+			name:         "nearest height below the bracket",
+			hubHeight:    100,
+			speeds:       map[float64]float64{10: 5},
+			wantSpeed:    5 * math.Pow(100.0/10, defaultShearExponent),
+			wantExponent: defaultShearExponent,
+		},
+		{
+			name:         "nearest height above the bracket",
+			hubHeight:    30,
+			speeds:       map[float64]float64{120: 10},
+			wantSpeed:    10 * math.Pow(30.0/120, defaultShearExponent),
+			wantExponent: defaultShearExponent,
+		},
+		{
+			name:         "no speed",
+			hubHeight:    100,
+			speeds:       map[float64]float64{},
+			wantSpeed:    0,
+			wantExponent: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			speed, exponent := hubWindSpeed(test.hubHeight, test.speeds)
+			if math.Abs(speed-test.wantSpeed) > 1e-9 || math.Abs(exponent-test.wantExponent) > 1e-9 {
+				t.Errorf("hubWindSpeed(%v, %v) = %v, %v, want %v, %v", test.hubHeight, test.speeds, speed, exponent, test.wantSpeed, test.wantExponent)
+			}
+		})
+	}
+}